@@ -0,0 +1,223 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// accessor implements the converting Reader calls on top of a Read function.
+// Embedding an accessor lets a Reader implementation provide the conversions by only implementing Read.
+type accessor struct {
+	read func(key string) (interface{}, error)
+}
+
+// ReadString behaves like Read with additional conversion taking place.
+// Values implementing encoding.TextMarshaler or fmt.Stringer, such as time.Duration or enumerations, are formatted by
+// their MarshalText or String method, while other slices, maps, arrays and structs are represented as JSON.
+func (a accessor) ReadString(key string) (string, error) {
+	v, err := a.read(key)
+	if err != nil {
+		return "", err
+	}
+	s, kerr := toString(v)
+	if kerr != nil {
+		kerr.From(key)
+		return "", kerr
+	}
+	return s, nil
+}
+
+// ReadStringMap behaves like the Reader's Read with the values of a map converted into strings like ReadString does,
+// keys being formatted as strings. Entries failing to convert are aggregated as Errors while any kind other than a map
+// results in an ErrIncompatibleType.
+func ReadStringMap(r Reader, key string) (map[string]string, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	val := indirect(reflect.ValueOf(v))
+	if val.Kind() != reflect.Map {
+		return nil, &ErrIncompatibleType{Type: "map[string]string", ConfigurationError: &ConfigurationError{key}}
+	}
+	m := make(map[string]string, val.Len())
+	var errs Errors
+	i := val.MapRange()
+	for i.Next() {
+		name := fmt.Sprint(i.Key().Interface())
+		s, kerr := toString(i.Value().Interface())
+		if kerr != nil {
+			prependKey(kerr, name, separatorOf(r))
+			prependKey(kerr, key, separatorOf(r))
+			errs = append(errs, kerr)
+			continue
+		}
+		m[name] = s
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Key() < errs[j].Key()
+		})
+		return nil, errs
+	}
+	return m, nil
+}
+
+// toString converts a value into its string representation.
+// Values implementing encoding.TextMarshaler or fmt.Stringer are formatted by their MarshalText or String method, while
+// other slices, maps, arrays and structs are represented as JSON.
+func toString(v interface{}) (string, KeyError) {
+	val := reflect.ValueOf(v)
+	// Format text marshalers and stringers such as time.Duration by their representation rather than their kind
+	if !(val.Kind() == reflect.Ptr && val.IsNil()) {
+		if m, ok := v.(encoding.TextMarshaler); ok {
+			b, err := m.MarshalText()
+			if err != nil {
+				return "", &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+			}
+			return string(b), nil
+		}
+		if s, ok := v.(fmt.Stringer); ok {
+			return s.String(), nil
+		}
+	}
+	switch k := val.Kind(); k {
+	case reflect.Invalid:
+		return "", nil
+	case reflect.String:
+		return val.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(val.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 64), nil
+	case reflect.Complex64:
+		return strconv.FormatComplex(val.Complex(), 'g', -1, 64), nil
+	case reflect.Complex128:
+		return strconv.FormatComplex(val.Complex(), 'g', -1, 128), nil
+	case reflect.Bool:
+		return strconv.FormatBool(val.Bool()), nil
+	case reflect.Slice, reflect.Map, reflect.Array, reflect.Struct:
+		// Keep byte slices as their raw string
+		if k == reflect.Slice && val.Type().Elem().Kind() == reflect.Uint8 {
+			return string(val.Bytes()), nil
+		}
+		// Represent composites as JSON
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+		}
+		return string(b), nil
+	default:
+		// Attempt conversion
+		t := reflect.TypeOf("")
+		if val.CanConvert(t) {
+			return val.Convert(t).String(), nil
+		}
+		// Error otherwise
+		return "", &ErrUnhandledKind{Kind: k.String(), ConfigurationError: &ConfigurationError{}}
+	}
+}
+
+// ReadByteSize behaves like the Reader's Read with additional byte size conversion taking place.
+// Strings such as `10MB` or `2GiB` are parsed into their number of bytes.
+func ReadByteSize(r Reader, key string) (int64, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	n, kerr := toByteSize(v)
+	if kerr != nil {
+		kerr.From(key)
+		return 0, kerr
+	}
+	return n, nil
+}
+
+// ReadRequired behaves like the Reader's Read, additionally returning an ErrEmptyValue when the value is the zero value
+// of its type or an empty map or slice.
+func ReadRequired(r Reader, key string) (interface{}, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	val := reflect.ValueOf(v)
+	if !val.IsValid() || val.IsZero() || ((val.Kind() == reflect.Map || val.Kind() == reflect.Slice) && val.Len() == 0) {
+		return nil, &ErrEmptyValue{&ConfigurationError{key}}
+	}
+	return v, nil
+}
+
+// ReadBool behaves like the Reader's Read with additional boolean conversion taking place.
+// Strings accepted by strconv.ParseBool as well as `yes`, `no`, `on` and `off` are parsed case-insensitively.
+func ReadBool(r Reader, key string) (bool, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return false, err
+	}
+	b, kerr := toBool(v)
+	if kerr != nil {
+		kerr.From(key)
+		return false, kerr
+	}
+	return b, nil
+}
+
+// toBool converts a value into a boolean.
+func toBool(v interface{}) (bool, KeyError) {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Bool:
+		return val.Bool(), nil
+	case reflect.String:
+		switch s := strings.ToLower(strings.TrimSpace(val.String())); s {
+		case "yes", "on":
+			return true, nil
+		case "no", "off":
+			return false, nil
+		default:
+			if b, err := strconv.ParseBool(s); err == nil {
+				return b, nil
+			}
+		}
+	}
+	return false, &ErrIncompatibleType{Type: "bool", ConfigurationError: &ConfigurationError{}}
+}
+
+// Has reports whether a key is present in the Reader, regardless of its value. Only an ErrNoSuchKey or
+// ErrIndexOutOfRange reports the key as absent.
+func Has(r Reader, key string) bool {
+	_, err := r.Read(key)
+	return !errors.Is(err, ErrKeyNotFound)
+}
+
+// ReadMany reads each key from the Reader, collecting the values and the errors of the keys failing to read separately,
+// such that a single failing key doesn't prevent reading the others. The errors are nil if all keys were read.
+func ReadMany(r Reader, keys ...string) (map[string]interface{}, map[string]error) {
+	values := make(map[string]interface{}, len(keys))
+	var errs map[string]error
+	for _, key := range keys {
+		v, err := r.Read(key)
+		if err != nil {
+			if errs == nil {
+				errs = make(map[string]error)
+			}
+			errs[key] = err
+			continue
+		}
+		values[key] = v
+	}
+	return values, errs
+}
@@ -0,0 +1,97 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// NewBatched abstracts a ReadWriter whose writes are queued in memory until the returned flush function applies them.
+//
+// Writes to the same key are coalesced, the last write winning, so that each key is written once per flush in the
+// order it was last written. Reads see the queued writes. Flushing applies the queued writes as a Patch, reverting the
+// applied writes and keeping the queue intact should one of them fail.
+func NewBatched(rw ReadWriter) (ReadWriter, func() error) {
+	b := &batched{RW: rw}
+	b.accessor = accessor{b.Read}
+	return b, b.flush
+}
+
+// batched is a ReadWriter whose writes are queued until flushed.
+type batched struct {
+	accessor
+	RW      ReadWriter
+	Pending Patch
+	mu      sync.Mutex
+}
+
+// Read is a wrapper around the Reader overlaying the queued writes.
+func (b *batched) Read(key string) (interface{}, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	v, err := b.RW.Read(key)
+	k := foldKey(b.RW, key)
+	sep := separatorOf(b.RW)
+	for _, op := range b.Pending {
+		p := foldKey(b.RW, op.Key)
+		switch {
+		case p == k:
+			v, err = op.Value, nil
+		case strings.HasPrefix(k, p+sep):
+			// The queued write replaced an ancestor of the key
+			s := snapshot(op.Value)
+			if v, err = New(&s, withSeparator(sep)).Read(key[len(p)+len(sep):]); err != nil {
+				if kerr, ok := err.(KeyError); ok {
+					kerr.From(op.Key)
+				}
+			}
+		case strings.HasPrefix(p, k+sep):
+			// The queued write altered a descendant of the key
+			if err != nil {
+				if !errors.Is(err, ErrKeyNotFound) {
+					continue
+				}
+				v = nil
+			}
+			s := snapshot(v)
+			c := New(&s, withSeparator(sep))
+			if err = c.Write(op.Key[len(k)+len(sep):], op.Value); err != nil {
+				if kerr, ok := err.(KeyError); ok {
+					kerr.From(key)
+				}
+				return nil, err
+			}
+			v = s
+		}
+	}
+	return v, err
+}
+
+// Write queues the value of a key, replacing any previously queued value of the same key.
+func (b *batched) Write(key string, v interface{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, op := range b.Pending {
+		if foldKey(b.RW, op.Key) == foldKey(b.RW, key) {
+			b.Pending = append(b.Pending[:i], b.Pending[i+1:]...)
+			break
+		}
+	}
+	b.Pending = append(b.Pending, Operation{Op: OpSet, Key: key, Value: v})
+	return nil
+}
+
+// flush applies the queued writes to the underlying ReadWriter.
+func (b *batched) flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.Pending.Apply(b.RW); err != nil {
+		return err
+	}
+	b.Pending = nil
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewBatched(t *testing.T) {
+	d := map[string]interface{}{"name": "demo", "server": map[string]interface{}{"host": "localhost"}}
+	var writes []string
+	backend := NewObservable(New(&d), WithObserver(func(key string, old, new interface{}) {
+		writes = append(writes, key)
+	}))
+	c, flush := NewBatched(backend)
+	for _, w := range []struct {
+		Key   string
+		Value interface{}
+	}{
+		{"name", "first"},
+		{"server.port", 8080},
+		{"name", "second"},
+	} {
+		if err := c.Write(w.Key, w.Value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reads see the queued writes
+	if v, err := c.Read("name"); err != nil {
+		t.Fatal(err)
+	} else if v != "second" {
+		t.Fatalf("expected %#v, got %#v", "second", v)
+	}
+	expected := map[string]interface{}{"host": "localhost", "port": 8080}
+	if v, err := c.Read("server"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(v, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, v)
+	}
+	// The backend is left untouched until flushed
+	if len(writes) != 0 || d["name"] != "demo" {
+		t.Fatalf("expected no writes, got %#v", writes)
+	}
+	if err := flush(); err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"server.port", "name"}; !reflect.DeepEqual(writes, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, writes)
+	}
+	if v, err := backend.Read("server.port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+}
+
+func TestNewBatched_Ancestor(t *testing.T) {
+	d := map[string]interface{}{}
+	c, _ := NewBatched(New(&d))
+	if err := c.Write("server", map[string]interface{}{"host": "localhost"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.ReadString("server.host"); err != nil {
+		t.Fatal(err)
+	} else if v != "localhost" {
+		t.Fatalf("expected %#v, got %#v", "localhost", v)
+	}
+	_, err := c.Read("server.port")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "server.port" {
+		t.Fatalf("expected %#v, got %#v", "server.port", e.Key())
+	}
+}
+
+func TestNewBatched_CaseSensitive(t *testing.T) {
+	d := map[string]string{"ID": "a"}
+	b, flush := NewBatched(NewCaseSensitive(&d))
+	for key, v := range map[string]string{"ID": "x", "id": "y"} {
+		if err := b.Write(key, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := flush(); err != nil {
+		t.Fatal(err)
+	} else if expected := map[string]string{"ID": "x", "id": "y"}; !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+}
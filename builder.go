@@ -0,0 +1,42 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+)
+
+// Builder programmatically assembles a nested `map[string]interface{}` configuration, for example for test fixtures
+// and defaults. Intermediate maps are created as needed.
+type Builder struct {
+	data map[string]interface{}
+	rw   ReadWriter
+	err  error
+}
+
+// NewBuilder creates a new empty Builder.
+func NewBuilder() *Builder {
+	d := map[string]interface{}{}
+	return &Builder{data: d, rw: New(d)}
+}
+
+// Set writes a key's value. Once a Set fails, the following ones are ignored and Err reports the failure.
+func (b *Builder) Set(key string, value interface{}) *Builder {
+	if b.err == nil {
+		b.err = b.rw.Write(key, value)
+	}
+	return b
+}
+
+// Err returns the first error encountered by Set.
+func (b *Builder) Err() error {
+	return b.err
+}
+
+// Build creates a new ReadWriter configuration from a copy of the assembled keys.
+func (b *Builder) Build() ReadWriter {
+	d := deepCopy(reflect.ValueOf(b.data), false).Interface().(map[string]interface{})
+	return New(&d)
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	b := NewBuilder().
+		Set("name", "demo").
+		Set("server.host", "localhost").
+		Set("server.port", 8080).
+		Set("server.tls.enabled", true)
+	if err := b.Err(); err != nil {
+		t.Fatal(err)
+	}
+	c := b.Build()
+	tests := map[string]string{
+		"name":               "demo",
+		"server.host":        "localhost",
+		"server.port":        "8080",
+		"server.tls.enabled": "true",
+	}
+	for key, expected := range tests {
+		if s, err := c.ReadString(key); err != nil {
+			t.Fatal(err)
+		} else if s != expected {
+			t.Fatalf("expected %#v, got %#v", expected, s)
+		}
+	}
+	// Builds are independent of later changes
+	b.Set("server.host", "remote")
+	if s, err := c.ReadString("server.host"); err != nil {
+		t.Fatal(err)
+	} else if s != "localhost" {
+		t.Fatalf("expected %#v, got %#v", "localhost", s)
+	}
+}
+
+func TestBuilder_Err(t *testing.T) {
+	b := NewBuilder().Set("name", "demo").Set("name.first", "demo").Set("other", "ignored")
+	if err := b.Err(); err == nil {
+		t.Fatal("expected error but got none")
+	}
+	if _, err := b.Build().Read("other"); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"strings"
+	"sync"
+)
+
+// CachedReader abstracts a Reader memoizing the values it reads.
+type CachedReader interface {
+	Reader
+	// Invalidate flushes the memoized values of a key, its ancestors and its descendants.
+	Invalidate(key string)
+	// InvalidateAll flushes all memoized values.
+	InvalidateAll()
+}
+
+// Cache abstracts a Reader memoizing the values successfully read, avoiding repeated reflective lookups of hot keys.
+// Typed reads such as ReadString are built on top of the memoized values.
+//
+// Cache assumes the underlying data is stable between invalidations. Writes, including those through other layers or
+// wrappers of the same data, must hence be followed by an Invalidate of the written key or an InvalidateAll.
+func Cache(r Reader) CachedReader {
+	c := &cache{R: r, Values: map[string]interface{}{}}
+	c.accessor = accessor{c.Read}
+	return c
+}
+
+// cache is a Reader memoizing the values it reads.
+type cache struct {
+	accessor
+	R      Reader
+	Values map[string]interface{}
+	mu     sync.RWMutex
+}
+
+// Read is a memoizing wrapper around the Reader.
+func (c *cache) Read(key string) (interface{}, error) {
+	k := foldKey(c.R, key)
+	c.mu.RLock()
+	v, ok := c.Values[k]
+	c.mu.RUnlock()
+	if ok {
+		return v, nil
+	}
+	v, err := c.R.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.Values[k] = v
+	c.mu.Unlock()
+	return v, nil
+}
+
+// Invalidate flushes the memoized values of a key, its ancestors and its descendants.
+func (c *cache) Invalidate(key string) {
+	k := foldKey(c.R, key)
+	sep := separatorOf(c.R)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for m := range c.Values {
+		if m == k || strings.HasPrefix(m, k+sep) || strings.HasPrefix(k, m+sep) {
+			delete(c.Values, m)
+		}
+	}
+}
+
+// InvalidateAll flushes all memoized values.
+func (c *cache) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Values = map[string]interface{}{}
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+// counter is a Reader counting the reads of the underlying Reader.
+type counter struct {
+	Reader
+	Reads int
+}
+
+func (c *counter) Read(key string) (interface{}, error) {
+	c.Reads++
+	return c.Reader.Read(key)
+}
+
+func TestCache(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"host": "localhost", "port": 80}}
+	rw := New(&d)
+	n := &counter{Reader: rw}
+	c := Cache(n)
+	for i := 0; i < 3; i++ {
+		if v, err := c.ReadString("server.host"); err != nil {
+			t.Fatal(err)
+		} else if v != "localhost" {
+			t.Fatalf("expected %#v, got %#v", "localhost", v)
+		}
+	}
+	if _, err := c.Read("server"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rw.Write("server.host", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	// Stale values are served until invalidated
+	if v, err := c.ReadString("server.host"); err != nil {
+		t.Fatal(err)
+	} else if v != "localhost" {
+		t.Fatalf("expected %#v, got %#v", "localhost", v)
+	}
+	c.Invalidate("server")
+	if v, err := c.ReadString("server.host"); err != nil {
+		t.Fatal(err)
+	} else if v != "example.com" {
+		t.Fatalf("expected %#v, got %#v", "example.com", v)
+	}
+	if err := rw.Write("server.port", 8080); err != nil {
+		t.Fatal(err)
+	}
+	c.InvalidateAll()
+	if v, err := ReadInt(c, "server.port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+	if n.Reads != 4 {
+		t.Fatalf("expected %#v, got %#v", 4, n.Reads)
+	}
+}
+
+func TestCache_CaseSensitive(t *testing.T) {
+	d := map[string]string{"ID": "a", "id": "b"}
+	c := Cache(NewCaseSensitive(&d))
+	for _, key := range []string{"ID", "id"} {
+		if v, err := c.ReadString(key); err != nil {
+			t.Fatal(err)
+		} else if v != d[key] {
+			t.Fatalf("expected %#v, got %#v", d[key], v)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"strings"
+)
+
+// CompiledKey abstracts a precompiled key which can be repeatedly read and written.
+type CompiledKey interface {
+	// Read gets the key's value from a ReadWriter.
+	Read(rw ReadWriter) (interface{}, error)
+	// Write sets the key's value on a ReadWriter.
+	Write(rw ReadWriter, v interface{}) error
+}
+
+// Compile precompiles a dot-separated key into a CompiledKey, such that its levels are split only once.
+//
+// The precompiled levels are used by configurations created by New. Other ReadWriter implementations, as well as
+// configurations using a custom separator or limiting the depth, are provided the key as-is.
+func Compile(key string) (CompiledKey, error) {
+	if key == "" {
+		return nil, &ErrNoSuchKey{&ConfigurationError{key}}
+	}
+	return &compiled{Key: key, Levels: strings.Split(key, ".")}, nil
+}
+
+// compiled is the CompiledKey of a precompiled key.
+type compiled struct {
+	Key    string
+	Levels []string
+}
+
+// Read gets the key's value from a ReadWriter.
+func (a *compiled) Read(rw ReadWriter) (interface{}, error) {
+	if c, ok := a.native(rw); ok {
+		return c.readLevels(context.Background(), a.Levels)
+	}
+	return rw.Read(a.Key)
+}
+
+// Write sets the key's value on a ReadWriter.
+func (a *compiled) Write(rw ReadWriter, v interface{}) error {
+	if c, ok := a.native(rw); ok {
+		return c.writeLevels(context.Background(), a.Key, a.Levels, v)
+	}
+	return rw.Write(a.Key, v)
+}
+
+// native returns the configuration if the precompiled levels can be used.
+func (a *compiled) native(rw ReadWriter) (*config, bool) {
+	c, ok := rw.(*config)
+	if !ok || c.Options.separator != "." || c.Options.maxDepth > 0 {
+		return nil, false
+	}
+	return c, true
+}
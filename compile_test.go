@@ -0,0 +1,61 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	d := map[string]*server{"default": {Host: "localhost", Port: 80}}
+	a, err := Compile("default.port")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rw := range []ReadWriter{New(&d), Synchronized(New(&d))} {
+		if err := a.Write(rw, 8080+i); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := a.Read(rw); err != nil {
+			t.Fatal(err)
+		} else if v != 8080+i {
+			t.Fatalf("expected %#v, got %#v", 8080+i, v)
+		}
+	}
+	if _, err := Compile(""); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+func TestCompile_MissingKey(t *testing.T) {
+	d := map[string]interface{}{"name": "foo"}
+	a, err := Compile("owner.name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = a.Read(New(&d))
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "owner" {
+		t.Fatalf("expected %#v, got %#v", "owner", e.Key())
+	}
+}
+
+func TestCompile_Separator(t *testing.T) {
+	d := map[string]interface{}{"database": map[string]interface{}{"host": "localhost"}}
+	a, err := Compile("database/host")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := a.Read(NewWithSeparator(&d, "/")); err != nil {
+		t.Fatal(err)
+	} else if v != "localhost" {
+		t.Fatalf("expected %#v, got %#v", "localhost", v)
+	}
+}
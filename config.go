@@ -5,9 +5,11 @@
 package config
 
 import (
+	"context"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Reader abstracts a readable configuration.
@@ -27,21 +29,98 @@ type ReadWriter interface {
 	Writer
 }
 
+// ZeroValuer abstracts a configuration able to provide blank values matching its schema.
+type ZeroValuer interface {
+	// ZeroValue returns a zero value of the type at key. Maps and slices provide the zero value of their elements.
+	ZeroValue(key string) (interface{}, error)
+}
+
 // New creates a new ReadWriter configuration linked to the interface v.
-func New(v interface{}) ReadWriter {
-	return &config{Data: v}
+func New(v interface{}, opts ...Option) ReadWriter {
+	c := &config{Data: v, Options: newOptions(opts)}
+	c.accessor = accessor{c.Read}
+	return c
+}
+
+// NewWithSeparator creates a new ReadWriter configuration linked to the interface v whose key levels are separated by
+// sep rather than a dot, allowing map keys such as hostnames or file paths to contain dots.
+func NewWithSeparator(v interface{}, sep string, opts ...Option) ReadWriter {
+	return New(v, append(opts, withSeparator(sep))...)
+}
+
+// NewStrict creates a new ReadWriter configuration linked to the interface v rejecting lossy numeric conversions.
+// Writing a number which can't be represented exactly by an integer destination, such as a float64 with a fractional
+// part, an out-of-range value or a negative value into an unsigned integer, results in an ErrIncompatibleType rather
+// than truncating.
+func NewStrict(v interface{}, opts ...Option) ReadWriter {
+	return New(v, append(opts, withStrictNumbers())...)
+}
+
+// NewCaseSensitive creates a new ReadWriter configuration linked to the interface v whose keys are matched exactly
+// rather than case-insensitively, allowing maps to hold distinct keys such as `ID` and `id`.
+//
+// Struct fields are then matched exactly as well, requiring keys to use the field's name, such as `Port`, or its
+// `config:"name"` tag.
+func NewCaseSensitive(v interface{}, opts ...Option) ReadWriter {
+	return New(v, append(opts, withCaseSensitive())...)
+}
+
+// separatorOf returns the separator between the key levels of a configuration, which defaults to a dot.
+func separatorOf(r interface{}) string {
+	if s, ok := r.(interface{ separator() string }); ok {
+		return s.separator()
+	}
+	return "."
+}
+
+// caseSensitiveOf reports whether a configuration matches its keys exactly, which defaults to case-insensitively.
+func caseSensitiveOf(r interface{}) bool {
+	if c, ok := r.(interface{ caseSensitive() bool }); ok {
+		return c.caseSensitive()
+	}
+	return false
+}
+
+// foldKey returns the form under which a configuration matches a key, lowercased unless it is case-sensitive.
+func foldKey(r interface{}, key string) string {
+	if caseSensitiveOf(r) {
+		return key
+	}
+	return strings.ToLower(key)
 }
 
 // config is a recursive ReadWriter implementation
 type config struct {
-	Data interface{}
+	accessor
+	Data    interface{}
+	Options options
 }
 
 // Write sets a key's value.
 func (c *config) Write(key string, value interface{}) error {
+	return c.WriteContext(context.Background(), key, value)
+}
+
+// WriteContext sets a key's value, aborting with an ErrCanceled once the context is done.
+func (c *config) WriteContext(ctx context.Context, key string, value interface{}) error {
+	k, err := c.split(key)
+	if err != nil {
+		return err
+	}
+	return c.writeLevels(ctx, key, k, value)
+}
+
+// writeLevels transforms and sets the value of a key split into its levels k.
+func (c *config) writeLevels(ctx context.Context, key string, k []string, value interface{}) error {
+	for _, transform := range c.Options.transforms {
+		v, err := transform(key, value)
+		if err != nil {
+			return err
+		}
+		value = v
+	}
 	d := reflect.ValueOf(c.Data)
-	k := strings.Split(key, ".")
-	v, err := c.write(k, d, value)
+	v, err := c.write(ctx, k, d, value)
 	if err != nil {
 		return err
 	}
@@ -51,25 +130,50 @@ func (c *config) Write(key string, value interface{}) error {
 
 // write recursively sets a key's value. It provides the inspected element and returns the modified element.
 // By providing a modified element, write introduces support for value-passed parameters in addition to reference-passed ones.
-func (c *config) write(key []string, element reflect.Value, value interface{}) (reflect.Value, KeyError) {
+func (c *config) write(ctx context.Context, key []string, element reflect.Value, value interface{}) (reflect.Value, KeyError) {
 	if len(key) == 0 {
+		if element.IsValid() {
+			v, err := handle(handledType(element), value, true)
+			if err != nil {
+				return element, err
+			}
+			value = v
+		}
 		return reflect.ValueOf(value), nil
 	}
+	if err := ctx.Err(); err != nil {
+		return element, &ErrCanceled{Err: err, ConfigurationError: &ConfigurationError{}}
+	}
 
 	switch k := element.Kind(); k {
 	case reflect.Interface:
 		e := element.Elem()
-		e, err := c.write(key, e, value)
+		// Build free-form trees from nil empty interfaces, other nil interfaces lacking a concrete type to allocate
+		if !e.IsValid() && element.NumMethod() == 0 {
+			e = reflect.ValueOf(map[string]interface{}{})
+		} else if !e.IsValid() {
+			return element, &ErrUnhandledKind{Kind: k.String(), ConfigurationError: &ConfigurationError{key[0]}}
+		}
+		e, err := c.write(ctx, key, e, value)
 		if err != nil {
 			return element, err
 		}
 		return reflect.ValueOf(e.Interface()), nil
 	case reflect.Ptr:
+		// Allocate nil pointers
+		if element.IsNil() {
+			element = reflect.New(element.Type().Elem())
+		}
 		e := element.Elem()
-		e, err := c.write(key, e, value)
+		e, err := c.write(ctx, key, e, value)
 		if err != nil {
 			return element, err
 		}
+		// Update the pointed value in place so that the original data reflects rebuilt values such as grown slices
+		if e.Type().AssignableTo(element.Type().Elem()) {
+			element.Elem().Set(e)
+			return element, nil
+		}
 		if e.CanAddr() {
 			return e.Addr(), nil
 		}
@@ -80,31 +184,32 @@ func (c *config) write(key []string, element reflect.Value, value interface{}) (
 		// Consume one key level
 		name := key[0]
 		key = key[1:]
-		// Loop the elements
+		// Find the matching exported field
 		t := element.Type()
-		for i := 0; i < t.NumField(); i++ {
-			f := t.Field(i)
-			if strings.EqualFold(name, f.Name) {
-				e := element.Field(i)
-				v, err := c.write(key, e, value)
-				if err != nil {
-					err.From(name)
-					return element, err
-				}
-				if !v.CanConvert(f.Type) {
-					return element, &ErrIncompatibleType{Type: f.Type.String(), ConfigurationError: &ConfigurationError{name}}
-				}
-				if !e.CanSet() {
-					n := reflect.Indirect(reflect.New(t))
-					n.Set(element)
-					element = n
-					e = n.Field(i)
-				}
-				e.Set(v.Convert(f.Type))
-				return element, nil
-			}
+		i, ok := structField(t, name, c.Options.caseSensitive)
+		if !ok {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		f := t.Field(i)
+		e := element.Field(i)
+		v, err := c.write(ctx, key, e, value)
+		if err != nil {
+			c.from(err, name)
+			return element, err
 		}
-		return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		v, err = c.convert(v, f.Type)
+		if err != nil {
+			c.from(err, name)
+			return element, err
+		}
+		if !e.CanSet() {
+			n := reflect.Indirect(reflect.New(t))
+			n.Set(element)
+			element = n
+			e = n.Field(i)
+		}
+		e.Set(v)
+		return element, nil
 	case reflect.Map:
 		// Consume one key level
 		name := key[0]
@@ -113,34 +218,75 @@ func (c *config) write(key []string, element reflect.Value, value interface{}) (
 		if element.IsNil() {
 			element = reflect.MakeMap(element.Type())
 		}
-		// Loop the elements
-		i := element.MapRange()
-		for i.Next() {
-			// Find a matching key
-			if strings.EqualFold(name, i.Key().String()) {
-				// Continue recursing on the value
-				e, err := c.write(key, i.Value(), value)
-				if err != nil {
-					err.From(name)
-					return element, err
-				}
-				// Update the map
-				element.SetMapIndex(i.Key(), e)
-				return element, nil
-			}
+		// Find a matching key
+		k, found, err := mapKey(element, name, c.Options.caseSensitive)
+		if err != nil {
+			return element, err
 		}
-		// Create a new value otherwise
 		t := element.Type().Elem()
-		e := reflect.Indirect(reflect.New(t))
-		e, err := c.write(key, e, value)
+		var e reflect.Value
+		if found {
+			e = element.MapIndex(k)
+		} else {
+			// Create a new value otherwise
+			e = c.prototype(t)
+			if c.Options.normalize != nil && k.Kind() == reflect.String {
+				k = reflect.ValueOf(c.Options.normalize(name)).Convert(k.Type())
+			}
+		}
+		// Continue recursing on the value
+		e, err = c.write(ctx, key, e, value)
 		if err != nil {
-			err.From(name)
+			c.from(err, name)
 			return element, err
 		}
-		if !e.CanConvert(t) {
-			return element, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{name}}
+		e, err = c.convert(e, t)
+		if err != nil {
+			c.from(err, name)
+			return element, err
 		}
-		element.SetMapIndex(reflect.ValueOf(name), e.Convert(t))
+		// Update the map
+		element.SetMapIndex(k, e)
+		return element, nil
+	case reflect.Slice, reflect.Array:
+		// Consume one key level
+		name := key[0]
+		key = key[1:]
+		// Append a new element when using the `-` token
+		if name == "-" && k == reflect.Slice {
+			element = reflect.Append(element, reflect.Zero(element.Type().Elem()))
+			name = strconv.Itoa(element.Len() - 1)
+		}
+		// Find the indexed element, arrays being unable to grow
+		i, perr := strconv.Atoi(name)
+		if name == "-" && k == reflect.Array {
+			i, perr = element.Len(), nil
+		}
+		if perr == nil && (i < 0 || i >= element.Len()) {
+			return element, &ErrIndexOutOfRange{Index: i, Len: element.Len(), ConfigurationError: &ConfigurationError{name}}
+		}
+		if perr != nil {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		// Copy arrays which can't be set in place
+		if !element.Index(i).CanSet() {
+			n := reflect.New(element.Type()).Elem()
+			n.Set(element)
+			element = n
+		}
+		// Continue recursing on the element
+		e, err := c.write(ctx, key, element.Index(i), value)
+		if err != nil {
+			c.from(err, name)
+			return element, err
+		}
+		e, err = c.convert(e, element.Type().Elem())
+		if err != nil {
+			c.from(err, name)
+			return element, err
+		}
+		// Update the element in place
+		element.Index(i).Set(e)
 		return element, nil
 	default:
 		name := key[0]
@@ -148,113 +294,365 @@ func (c *config) write(key []string, element reflect.Value, value interface{}) (
 	}
 }
 
+// split splits a key into its levels, enforcing the maximal depth.
+func (c *config) split(key string) ([]string, KeyError) {
+	k := strings.Split(key, c.Options.separator)
+	if max := c.Options.maxDepth; max > 0 && len(k) > max {
+		return nil, &ErrLimitExceeded{Limit: "depth", Max: max, ConfigurationError: &ConfigurationError{key}}
+	}
+	return k, nil
+}
+
+// join joins levels into a key.
+func (c *config) join(key []string) string {
+	return strings.Join(key, c.Options.separator)
+}
+
+// separator returns the separator between key levels.
+func (c *config) separator() string {
+	return c.Options.separator
+}
+
+// caseSensitive reports whether keys are matched exactly.
+func (c *config) caseSensitive() bool {
+	return c.Options.caseSensitive
+}
+
+// from prepends a KeyError's key with the provided level using the configured separator.
+func (c *config) from(err KeyError, key string) {
+	prependKey(err, key, c.Options.separator)
+}
+
+// prependKey prepends a KeyError's key with the provided level using the separator.
+func prependKey(err KeyError, key, sep string) {
+	if p, ok := err.(interface{ prepend(key, sep string) }); ok {
+		p.prepend(key, sep)
+		return
+	}
+	err.From(key)
+}
+
+// fieldIndexes caches the index of every struct type's fields by their key, lowercased unless matched exactly.
+var fieldIndexes sync.Map
+
+// fieldIndexKey identifies a cached struct field index.
+type fieldIndexKey struct {
+	Type  reflect.Type
+	Exact bool
+}
+
+// structField finds the index of the exported struct field matching a key, case-insensitively unless exact.
+// Fields are matched by their `config:"name"` tag if any, or otherwise by their name, while fields tagged with
+// `config:"-"` never match. The matching fields of a type are indexed on first use.
+func structField(t reflect.Type, name string, exact bool) (int, bool) {
+	key := fieldIndexKey{Type: t, Exact: exact}
+	index, ok := fieldIndexes.Load(key)
+	if !ok {
+		index, _ = fieldIndexes.LoadOrStore(key, fieldIndex(t, exact))
+	}
+	if !exact {
+		name = strings.ToLower(name)
+	}
+	i, ok := index.(map[string]int)[name]
+	return i, ok
+}
+
+// fieldIndex indexes the exported struct fields by their key, lowercased unless exact, the first field winning on
+// collisions.
+func fieldIndex(t reflect.Type, exact bool) map[string]int {
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if key, ok := fieldName(f, exact); ok {
+			if !exact {
+				key = strings.ToLower(key)
+			}
+			if _, exists := index[key]; !exists {
+				index[key] = i
+			}
+		}
+	}
+	return index
+}
+
+// mapKey finds the key of a map matching a key segment, string keys being matched case-insensitively unless exact.
+// When no key matches, the segment parsed into the map's key type is returned instead. Segments which can't be parsed
+// into the map's key type result in an ErrIncompatibleType.
+func mapKey(element reflect.Value, name string, exact bool) (reflect.Value, bool, KeyError) {
+	t := element.Type().Key()
+	if t.Kind() == reflect.String {
+		k := reflect.ValueOf(name).Convert(t)
+		if exact {
+			return k, element.MapIndex(k).IsValid(), nil
+		}
+		i := element.MapRange()
+		for i.Next() {
+			if strings.EqualFold(name, i.Key().String()) {
+				return i.Key(), true, nil
+			}
+		}
+		return k, false, nil
+	}
+	k, ok := unmarshalText(name, t)
+	if !k.IsValid() {
+		k, ok = reflect.New(t).Elem(), true
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := strconv.ParseInt(name, 10, t.Bits())
+			k.SetInt(i)
+			ok = err == nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			u, err := strconv.ParseUint(name, 10, t.Bits())
+			k.SetUint(u)
+			ok = err == nil
+		case reflect.Float32, reflect.Float64:
+			f, err := strconv.ParseFloat(name, t.Bits())
+			k.SetFloat(f)
+			ok = err == nil
+		case reflect.Bool:
+			b, err := strconv.ParseBool(name)
+			k.SetBool(b)
+			ok = err == nil
+		case reflect.Interface:
+			ok = reflect.TypeOf(name).AssignableTo(t)
+			if ok {
+				k.Set(reflect.ValueOf(name))
+			}
+		default:
+			ok = false
+		}
+	}
+	if !ok {
+		return reflect.Value{}, false, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{name}}
+	}
+	return k, element.MapIndex(k).IsValid(), nil
+}
+
 // Read gets a key's value.
 func (c *config) Read(key string) (interface{}, error) {
+	return c.ReadContext(context.Background(), key)
+}
+
+// ReadContext gets a key's value, aborting with an ErrCanceled once the context is done.
+func (c *config) ReadContext(ctx context.Context, key string) (interface{}, error) {
+	k, err := c.split(key)
+	if err != nil {
+		return nil, err
+	}
+	return c.readLevels(ctx, k)
+}
+
+// readLevels gets the value of a key split into its levels.
+func (c *config) readLevels(ctx context.Context, key []string) (interface{}, error) {
 	d := reflect.ValueOf(c.Data)
-	k := strings.Split(key, ".")
-	return c.read(k, d)
+	v, err := c.read(ctx, key, d)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	r, err := handle(handledType(v), v.Interface(), false)
+	if err != nil {
+		err.From(c.join(key))
+		return nil, err
+	}
+	return r, nil
 }
 
-// read recursively gets a key's value. It provides the inspected element and returns the final value.
-func (c *config) read(key []string, element reflect.Value) (interface{}, KeyError) {
+// read recursively gets a key's value. It provides the inspected element and returns the final element.
+func (c *config) read(ctx context.Context, key []string, element reflect.Value) (reflect.Value, KeyError) {
 	if len(key) == 0 {
-		return element.Interface(), nil
+		return element, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return element, &ErrCanceled{Err: err, ConfigurationError: &ConfigurationError{}}
 	}
 
 	switch k := element.Kind(); k {
 	case reflect.Interface:
+		// Nil interfaces can't be descended
+		if element.IsNil() {
+			return reflect.Value{}, &ErrNoSuchKey{&ConfigurationError{key[0]}}
+		}
 		e := element.Elem()
-		return c.read(key, e)
+		return c.read(ctx, key, e)
 	case reflect.Ptr:
+		// Nil pointers can't be descended
+		if element.IsNil() {
+			return reflect.Value{}, &ErrNoSuchKey{&ConfigurationError{key[0]}}
+		}
 		e := element.Elem()
-		return c.read(key, e)
+		return c.read(ctx, key, e)
 	case reflect.Struct:
 		// Consume one key level
 		name := key[0]
 		key = key[1:]
-		// Loop the elements
-		t := element.Type()
-		for i := 0; i < t.NumField(); i++ {
-			f := t.Field(i)
-			if strings.EqualFold(name, f.Name) {
-				e := element.Field(i)
-				v, err := c.read(key, e)
-				if err != nil {
-					err.From(name)
-					return v, err
-				}
-				return v, nil
-			}
+		// Find the matching exported field
+		i, ok := structField(element.Type(), name, c.Options.caseSensitive)
+		if !ok {
+			return reflect.Value{}, &ErrNoSuchKey{&ConfigurationError{name}}
 		}
-		return nil, &ErrNoSuchKey{&ConfigurationError{name}}
+		v, err := c.read(ctx, key, element.Field(i))
+		if err != nil {
+			c.from(err, name)
+			return v, err
+		}
+		return v, nil
 	case reflect.Map:
 		// Consume one key level
 		name := key[0]
 		key = key[1:]
 		// Ensure the map is not nil
 		if element.IsNil() {
-			return nil, &ErrNoSuchKey{&ConfigurationError{name}}
+			return reflect.Value{}, &ErrNoSuchKey{&ConfigurationError{name}}
 		}
-		// Loop the elements
-		i := element.MapRange()
-		for i.Next() {
-			// Find a matching key
-			if strings.EqualFold(name, i.Key().String()) {
-				// Continue recursing on the value
-				v, err := c.read(key, i.Value())
-				if err != nil {
-					err.From(name)
-					return v, err
-				}
-				return v, nil
-			}
+		// Find a matching key
+		k, found, err := mapKey(element, name, c.Options.caseSensitive)
+		if err != nil {
+			return reflect.Value{}, err
+		} else if !found {
+			return reflect.Value{}, &ErrNoSuchKey{&ConfigurationError{name}}
 		}
-		return nil, &ErrNoSuchKey{&ConfigurationError{name}}
+		// Continue recursing on the value
+		v, err := c.read(ctx, key, element.MapIndex(k))
+		if err != nil {
+			c.from(err, name)
+			return v, err
+		}
+		return v, nil
+	case reflect.Slice, reflect.Array:
+		// Consume one key level
+		name := key[0]
+		key = key[1:]
+		// Find the indexed element
+		i, perr := strconv.Atoi(name)
+		if perr == nil && (i < 0 || i >= element.Len()) {
+			return reflect.Value{}, &ErrIndexOutOfRange{Index: i, Len: element.Len(), ConfigurationError: &ConfigurationError{name}}
+		}
+		if perr != nil {
+			return reflect.Value{}, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		v, err := c.read(ctx, key, element.Index(i))
+		if err != nil {
+			c.from(err, name)
+			return v, err
+		}
+		return v, nil
 	default:
 		name := key[0]
-		return element, &ErrUnhandledKind{Kind: k.String(), ConfigurationError: &ConfigurationError{name}}
+		return reflect.Value{}, &ErrUnhandledKind{Kind: k.String(), ConfigurationError: &ConfigurationError{name}}
 	}
 }
 
-// ReadString behaves like Read with additional conversion taking place.
-func (c *config) ReadString(key string) (string, error) {
-	v, err := c.Read(key)
+// ZeroValue returns a zero value of the type at key. Maps and slices provide the zero value of their elements.
+//
+// ZeroValue allows editors to pre-populate a blank entry matching the schema, for example before writing a new map entry.
+func (c *config) ZeroValue(key string) (interface{}, error) {
+	d := reflect.ValueOf(c.Data)
+	k, err := c.split(key)
 	if err != nil {
-		return "", err
-	}
-	val := reflect.ValueOf(v)
-	switch k := val.Kind(); k {
-	case reflect.String:
-		return val.String(), err
-	case reflect.Int, reflect.Int8, reflect.Int32, reflect.Int64:
-		return strconv.FormatInt(val.Int(), 10), nil
-	case reflect.Float32:
-		return strconv.FormatFloat(val.Float(), 'g', -1, 32), nil
-	case reflect.Float64:
-		return strconv.FormatFloat(val.Float(), 'g', -1, 64), nil
-	case reflect.Complex64:
-		return strconv.FormatComplex(val.Complex(), 'g', -1, 64), nil
-	case reflect.Complex128:
-		return strconv.FormatComplex(val.Complex(), 'g', -1, 128), nil
-	case reflect.Bool:
-		return strconv.FormatBool(val.Bool()), nil
-	default:
-		// Attempt conversion
-		t := reflect.TypeOf("")
-		if val.CanConvert(t) {
-			return val.Convert(t).String(), nil
-		}
-		// Error otherwise
-		return "", &ErrUnhandledKind{Kind: k.String(), ConfigurationError: &ConfigurationError{key}}
+		return nil, err
+	}
+	v, err := c.read(context.Background(), k, d)
+	if err != nil {
+		return nil, err
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	t := v.Type()
+	if v.Kind() == reflect.Interface && !v.IsNil() {
+		t = v.Elem().Type()
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
 	}
+	switch t.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		t = t.Elem()
+	}
+	return reflect.Zero(t).Interface(), nil
 }
 
 // Sub abstracts a ReadWriter sub-configuration by prefixing all keyed calls with a prefix.
 //
 // Sub allows for abstractions such as profiles where all `my.key` can be prefixed for example by `profiles.default`,
-// resulting in the `profiles.default.my.key` key.
+// resulting in the `profiles.default.my.key` key. Nested sub-configurations are flattened into a single prefix.
 func Sub(rw ReadWriter, prefix string) ReadWriter {
-	return &sub{RW: rw, Prefix: prefix}
+	if s, ok := rw.(*sub); ok {
+		return &sub{subReader: newSubReader(s.RW, s.resolve(prefix)), RW: s.RW}
+	}
+	return &sub{subReader: newSubReader(rw, prefix), RW: rw}
+}
+
+// SubReader abstracts a Reader sub-configuration by prefixing all keyed calls with a prefix, like Sub does for a
+// ReadWriter.
+func SubReader(r Reader, prefix string) Reader {
+	switch s := r.(type) {
+	case *sub:
+		return newSubReader(s.RW, s.resolve(prefix))
+	case *subReader:
+		return newSubReader(s.R, s.resolve(prefix))
+	}
+	return newSubReader(r, prefix)
+}
+
+// newSubReader creates a prefixing Reader.
+func newSubReader(r Reader, prefix string) *subReader {
+	return &subReader{R: r, Prefix: prefix}
+}
+
+// subReader is a Reader sub-configuration, prefixing all keyed calls with a prefix.
+type subReader struct {
+	R      Reader
+	Prefix string
+}
+
+// resolve prefixes a key with the sub prefix.
+func (s *subReader) resolve(key string) string {
+	return s.Prefix + s.separator() + key
+}
+
+// relative strips the sub prefix from a fully-qualified key, reporting whether the key is below the prefix.
+// relative is the inverse of resolve.
+func (s *subReader) relative(key string) (string, bool) {
+	p := s.Prefix + s.separator()
+	if len(key) < len(p) || !s.equal(key[:len(p)], p) {
+		return key, false
+	}
+	return key[len(p):], true
+}
+
+// equal reports whether two keys match, ignoring case unless the underlying Reader matches keys exactly.
+func (s *subReader) equal(a, b string) bool {
+	if s.caseSensitive() {
+		return a == b
+	}
+	return strings.EqualFold(a, b)
+}
+
+// separator returns the separator between key levels of the underlying Reader.
+func (s *subReader) separator() string {
+	return separatorOf(s.R)
+}
+
+// caseSensitive reports whether the underlying Reader matches keys exactly.
+func (s *subReader) caseSensitive() bool {
+	return caseSensitiveOf(s.R)
+}
+
+// Read is a prefixed wrapper around the Reader.
+func (s *subReader) Read(key string) (interface{}, error) {
+	return s.R.Read(s.resolve(key))
+}
+
+// ReadString is a prefixed wrapper around the Reader.
+func (s *subReader) ReadString(key string) (string, error) {
+	return s.R.ReadString(s.resolve(key))
 }
 
 // sub is a ReadWriter sub-configuration, prefixing all keyed calls with a prefix.
@@ -262,26 +660,58 @@ func Sub(rw ReadWriter, prefix string) ReadWriter {
 // sub allows for abstractions such as profiles where all `my.key` can be prefixed for example by `profiles.default`,
 // resulting in the `profiles.default.my.key` key.
 type sub struct {
-	RW     ReadWriter
-	Prefix string
+	*subReader
+	RW ReadWriter
 }
 
-// resolve prefixes a key with the sub prefix.
-func (s *sub) resolve(key string) string {
-	return s.Prefix + "." + key
+// Strip abstracts a ReadWriter sub-configuration like Sub does, rejecting keys which already start with the prefix
+// with an ErrAbsoluteKey so that callers are forced to use keys relative to the prefix.
+func Strip(rw ReadWriter, prefix string) ReadWriter {
+	s := &stripped{S: Sub(rw, prefix).(*sub)}
+	s.accessor = accessor{s.Read}
+	return s
 }
 
-// Read is a prefixed wrapper around the Reader.
-func (s *sub) Read(key string) (interface{}, error) {
-	return s.RW.Read(s.resolve(key))
+// stripped is a ReadWriter sub-configuration rejecting fully-qualified keys.
+type stripped struct {
+	accessor
+	S *sub
 }
 
-// ReadString is a prefixed wrapper around the Reader.
-func (s *sub) ReadString(key string) (string, error) {
-	return s.RW.ReadString(s.resolve(key))
+// check rejects keys which start with the prefix.
+func (s *stripped) check(key string) error {
+	if _, ok := s.S.relative(key); ok || s.S.equal(key, s.S.Prefix) {
+		return &ErrAbsoluteKey{Prefix: s.S.Prefix, ConfigurationError: &ConfigurationError{key}}
+	}
+	return nil
+}
+
+// Read is a prefixed wrapper around the Reader rejecting fully-qualified keys.
+func (s *stripped) Read(key string) (interface{}, error) {
+	if err := s.check(key); err != nil {
+		return nil, err
+	}
+	return s.S.Read(key)
+}
+
+// Write is a prefixed wrapper around the Writer rejecting fully-qualified keys.
+func (s *stripped) Write(key string, v interface{}) error {
+	if err := s.check(key); err != nil {
+		return err
+	}
+	return s.S.Write(key, v)
 }
 
 // Write is a prefixed wrapper around Writer.
 func (s *sub) Write(key string, v interface{}) error {
 	return s.RW.Write(s.resolve(key), v)
 }
+
+// ZeroValue is a prefixed wrapper around the ZeroValuer.
+func (s *subReader) ZeroValue(key string) (interface{}, error) {
+	z, ok := s.R.(ZeroValuer)
+	if !ok {
+		return nil, &ErrUnsupported{Operation: "zero values", ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	return z.ZeroValue(s.resolve(key))
+}
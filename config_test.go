@@ -6,7 +6,10 @@ package config
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestConfig_WriteStructString(t *testing.T) {
@@ -133,7 +136,7 @@ func TestConfig_WriteRecursive(t *testing.T) {
 	}
 }
 
-func ExampleConfig_ReadString() {
+func ExampleReadWriter_ReadString() {
 	type Config struct {
 		My            string
 		Exotic        map[string]Config
@@ -149,7 +152,7 @@ func ExampleConfig_ReadString() {
 	// Output: Demo
 }
 
-func ExampleConfig_Write() {
+func ExampleReadWriter_Write() {
 	type Config struct {
 		My            string
 		Exotic        map[string]Config
@@ -165,7 +168,7 @@ func ExampleConfig_Write() {
 	// Output: Hello World!
 }
 
-func ExampleConfig_ReadStringComplex() {
+func ExampleReadWriter_Write_complex() {
 	type Config struct {
 		My            string
 		Exotic        map[string]Config
@@ -179,4 +182,1150 @@ func ExampleConfig_ReadStringComplex() {
 		fmt.Println(demo.Exotic["exotic"].Exotic["exotic"].My)
 	}
 	// Output: Success!
-}
\ No newline at end of file
+}
+
+func TestConfig_WriteStructUnexported(t *testing.T) {
+	type data struct {
+		Foo string
+		bar string
+	}
+	d := data{}
+	c := New(&d)
+	err := c.Write("bar", "baz")
+	if err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if d.bar != "" {
+		t.Fatalf("expected %#v, got %#v", "", d.bar)
+	}
+}
+
+func TestConfig_ReadStructUnexported(t *testing.T) {
+	type data struct {
+		Foo string
+		bar string
+	}
+	d := data{bar: "baz"}
+	c := New(&d)
+	if _, err := c.Read("bar"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestConfig_ZeroValue(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name    string
+		Servers map[string]server
+		Tags    []string
+	}
+	d := &data{Name: "demo", Servers: map[string]server{"default": {Host: "localhost", Port: 80}}}
+	c := New(d).(ZeroValuer)
+	tests := map[string]interface{}{
+		"name":            "",
+		"servers":         server{},
+		"servers.default": server{},
+		"tags":            "",
+	}
+	for key, expected := range tests {
+		if v, err := c.ZeroValue(key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	if _, err := c.ZeroValue("servers.default.missing"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestConfig_WriteStructNilPointer(t *testing.T) {
+	type b struct {
+		C string
+	}
+	type a struct {
+		B *b
+	}
+	type data struct {
+		A a
+	}
+	d := &data{}
+	c := New(d)
+	v := "c"
+	if err := c.Write("a.b.c", v); err != nil {
+		t.Fatal(err)
+	} else if d.A.B == nil {
+		t.Fatal("expected pointer to be allocated")
+	} else if d.A.B.C != v {
+		t.Fatalf("expected %#v, got %#v", v, d.A.B.C)
+	}
+}
+
+func TestConfig_ReadNilPointer(t *testing.T) {
+	type database struct {
+		Host string
+	}
+	type data struct {
+		Database *database
+	}
+	d := &data{}
+	c := New(d)
+	if _, err := c.Read("database.host"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "database.host" {
+		t.Fatalf("expected %#v, got %#v", "database.host", e.Key())
+	}
+	if err := c.Write("database.host", "localhost"); err != nil {
+		t.Fatal(err)
+	} else if d.Database == nil || d.Database.Host != "localhost" {
+		t.Fatalf("expected %#v, got %#v", &database{Host: "localhost"}, d.Database)
+	}
+}
+
+func TestConfig_WriteTransform(t *testing.T) {
+	type data struct {
+		Host string
+		Port int
+	}
+	d := &data{}
+	lower := WithWriteTransform(func(key string, v interface{}) (interface{}, error) {
+		if s, ok := v.(string); ok {
+			return strings.ToLower(s), nil
+		}
+		return v, nil
+	})
+	reject := WithWriteTransform(func(key string, v interface{}) (interface{}, error) {
+		if i, ok := v.(int); ok && i < 0 {
+			return nil, fmt.Errorf("negative %#v", key)
+		}
+		return v, nil
+	})
+	c := New(d, lower, reject)
+	if err := c.Write("host", "Example.COM"); err != nil {
+		t.Fatal(err)
+	} else if d.Host != "example.com" {
+		t.Fatalf("expected %#v, got %#v", "example.com", d.Host)
+	}
+	if err := c.Write("port", 8080); err != nil {
+		t.Fatal(err)
+	} else if d.Port != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, d.Port)
+	}
+	if err := c.Write("port", -1); err == nil {
+		t.Fatal("expected error but got none")
+	} else if d.Port != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, d.Port)
+	}
+}
+
+func TestConfig_WriteSliceNilPointer(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	type data struct {
+		Items []*item
+	}
+	d := &data{Items: make([]*item, 2)}
+	c := New(d)
+	if err := c.Write("items.1.name", "second"); err != nil {
+		t.Fatal(err)
+	} else if d.Items[0] != nil {
+		t.Fatalf("expected %#v, got %#v", nil, d.Items[0])
+	} else if d.Items[1] == nil {
+		t.Fatal("expected pointer to be allocated")
+	} else if d.Items[1].Name != "second" {
+		t.Fatalf("expected %#v, got %#v", "second", d.Items[1].Name)
+	}
+	if err := c.Write("items.2.name", "third"); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+func TestConfig_WriteNoCoercion(t *testing.T) {
+	type data struct {
+		Foo int
+		Bar interface{}
+	}
+	d := &data{}
+	if err := New(d).Write("foo", 12.0); err != nil {
+		t.Fatal(err)
+	} else if d.Foo != 12 {
+		t.Fatalf("expected %#v, got %#v", 12, d.Foo)
+	}
+	c := New(d, WithNoCoercion())
+	if err := c.Write("foo", 13.0); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if d.Foo != 12 {
+		t.Fatalf("expected %#v, got %#v", 12, d.Foo)
+	}
+	if err := c.Write("foo", 14); err != nil {
+		t.Fatal(err)
+	} else if d.Foo != 14 {
+		t.Fatalf("expected %#v, got %#v", 14, d.Foo)
+	}
+	if err := c.Write("bar", 15.0); err != nil {
+		t.Fatal(err)
+	} else if d.Bar != 15.0 {
+		t.Fatalf("expected %#v, got %#v", 15.0, d.Bar)
+	}
+}
+
+func TestConfig_WriteNormalizedMapKeys(t *testing.T) {
+	d := map[string]string{"foo": "foo"}
+	c := New(&d, WithNormalizedMapKeys(strings.ToLower))
+	if err := c.Write("Bar", "bar"); err != nil {
+		t.Fatal(err)
+	} else if v, ok := d["bar"]; !ok {
+		t.Fatal("expected normalized key to be set")
+	} else if v != "bar" {
+		t.Fatalf("expected %#v, got %#v", "bar", v)
+	} else if _, ok := d["Bar"]; ok {
+		t.Fatal("expected key to be normalized")
+	}
+	if err := c.Write("FOO", "baz"); err != nil {
+		t.Fatal(err)
+	} else if len(d) != 2 {
+		t.Fatalf("expected %#v entries, got %#v", 2, len(d))
+	} else if d["foo"] != "baz" {
+		t.Fatalf("expected %#v, got %#v", "baz", d["foo"])
+	}
+}
+
+func TestConfig_ReadNilInterface(t *testing.T) {
+	type data struct {
+		Foo interface{}
+	}
+	c := New(&data{})
+	if v, err := c.Read("foo"); err != nil {
+		t.Fatal(err)
+	} else if v != nil {
+		t.Fatalf("expected %#v, got %#v", nil, v)
+	}
+	if _, err := c.Read("foo.bar"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "foo.bar" {
+		t.Fatalf("expected %#v, got %#v", "foo.bar", e.Key())
+	}
+}
+
+func TestConfig_PointerChain(t *testing.T) {
+	type leaf struct {
+		Value string
+	}
+	type data struct {
+		Int    **int
+		Struct ***leaf
+	}
+	d := &data{}
+	c := New(d)
+	if _, err := c.Read("struct.value"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	if err := c.Write("int", 5); err != nil {
+		t.Fatal(err)
+	} else if d.Int == nil || *d.Int == nil || **d.Int != 5 {
+		t.Fatalf("expected %#v to be set", 5)
+	}
+	if err := c.Write("struct.value", "deep"); err != nil {
+		t.Fatal(err)
+	} else if d.Struct == nil || *d.Struct == nil || **d.Struct == nil || (***d.Struct).Value != "deep" {
+		t.Fatalf("expected %#v to be set", "deep")
+	}
+	if s, err := c.ReadString("struct.value"); err != nil {
+		t.Fatal(err)
+	} else if s != "deep" {
+		t.Fatalf("expected %#v, got %#v", "deep", s)
+	}
+}
+
+func TestConfig_WriteBytes(t *testing.T) {
+	type data struct {
+		Blob []byte
+	}
+	d := &data{}
+	if err := New(d).Write("blob", "aGVsbG8="); err != nil {
+		t.Fatal(err)
+	} else if string(d.Blob) != "aGVsbG8=" {
+		t.Fatalf("expected %#v, got %#v", "aGVsbG8=", string(d.Blob))
+	}
+	c := New(d, WithBase64Bytes())
+	if err := c.Write("blob", "aGVsbG8="); err != nil {
+		t.Fatal(err)
+	} else if string(d.Blob) != "hello" {
+		t.Fatalf("expected %#v, got %#v", "hello", string(d.Blob))
+	}
+	if err := c.Write("blob", "not base64!"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestConfig_Write_Prototype(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+		Tags []string
+	}
+	proto := server{Host: "localhost", Port: 80, Tags: []string{"default"}}
+	servers := map[string]*server{}
+	c := New(&servers, WithPrototype(func() interface{} { return proto }))
+	if err := c.Write("backup.port", 8080); err != nil {
+		t.Fatal(err)
+	}
+	expected := &server{Host: "localhost", Port: 8080, Tags: []string{"default"}}
+	if !reflect.DeepEqual(servers["backup"], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, servers["backup"])
+	}
+	// The prototype must be copied rather than shared
+	servers["backup"].Tags[0] = "modified"
+	if proto.Tags[0] != "default" {
+		t.Fatalf("expected %#v, got %#v", "default", proto.Tags[0])
+	}
+}
+
+func TestReadRequired(t *testing.T) {
+	d := map[string]interface{}{
+		"string": "",
+		"int":    0,
+		"bool":   false,
+		"map":    map[string]interface{}{},
+		"slice":  []interface{}{},
+		"nil":    nil,
+		"set":    "demo",
+	}
+	c := New(&d)
+	for _, key := range []string{"string", "int", "bool", "map", "slice", "nil"} {
+		_, err := ReadRequired(c, key)
+		if e, ok := err.(*ErrEmptyValue); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if e.Key() != key {
+			t.Fatalf("expected %#v, got %#v", key, e.Key())
+		}
+	}
+	if v, err := ReadRequired(c, "set"); err != nil {
+		t.Fatal(err)
+	} else if v != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", v)
+	}
+}
+
+func TestReadBool(t *testing.T) {
+	tests := map[interface{}]bool{
+		true:    true,
+		false:   false,
+		"true":  true,
+		"1":     true,
+		"YES":   true,
+		"On":    true,
+		"f":     false,
+		"0":     false,
+		"no":    false,
+		" off ": false,
+	}
+	for v, expected := range tests {
+		c := New(&map[string]interface{}{"enabled": v})
+		if b, err := ReadBool(c, "enabled"); err != nil {
+			t.Fatal(err)
+		} else if b != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, v, b)
+		}
+	}
+	for _, v := range []interface{}{"maybe", 1, nil} {
+		c := New(&map[string]interface{}{"enabled": v})
+		_, err := ReadBool(c, "enabled")
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error for %#v, got %T error", e, v, err)
+		} else if e.Key() != "enabled" {
+			t.Fatalf("expected %#v, got %#v", "enabled", e.Key())
+		}
+	}
+}
+
+func TestConfig_ReadWriteSliceIndex(t *testing.T) {
+	type server struct {
+		Host string
+	}
+	type data struct {
+		Servers []server
+		Ports   [2]int
+	}
+	d := &data{Servers: []server{{Host: "first"}, {Host: "second"}}, Ports: [2]int{80, 443}}
+	c := New(d)
+	if v, err := c.ReadString("servers.1.host"); err != nil {
+		t.Fatal(err)
+	} else if v != "second" {
+		t.Fatalf("expected %#v, got %#v", "second", v)
+	}
+	if v, err := c.Read("ports.0"); err != nil {
+		t.Fatal(err)
+	} else if v != 80 {
+		t.Fatalf("expected %#v, got %#v", 80, v)
+	}
+	for _, key := range []string{"servers.2.host", "servers.-1.host"} {
+		if _, err := c.Read(key); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		} else if e, ok := err.(*ErrIndexOutOfRange); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if k := strings.TrimSuffix(key, ".host"); e.Key() != k {
+			t.Fatalf("expected %#v, got %#v", k, e.Key())
+		}
+	}
+	if _, err := c.Read("ports.two"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	if err := c.Write("servers.0.host", "updated"); err != nil {
+		t.Fatal(err)
+	} else if d.Servers[0].Host != "updated" {
+		t.Fatalf("expected %#v, got %#v", "updated", d.Servers[0].Host)
+	}
+	if err := c.Write("ports.1", 8443); err != nil {
+		t.Fatal(err)
+	} else if d.Ports[1] != 8443 {
+		t.Fatalf("expected %#v, got %#v", 8443, d.Ports[1])
+	}
+	// Arrays held by interfaces are copied rather than set in place
+	m := map[string]interface{}{"ports": [2]int{80, 443}}
+	if err := New(&m).Write("ports.0", 8080); err != nil {
+		t.Fatal(err)
+	} else if expected := [2]int{8080, 443}; m["ports"] != expected {
+		t.Fatalf("expected %#v, got %#v", expected, m["ports"])
+	}
+}
+
+func TestConfig_WriteSliceAppend(t *testing.T) {
+	type server struct {
+		Host string
+	}
+	type data struct {
+		Servers []server
+	}
+	d := &data{Servers: []server{{Host: "first"}}}
+	c := New(d)
+	if err := c.Write("servers.-.host", "second"); err != nil {
+		t.Fatal(err)
+	} else if expected := []server{{Host: "first"}, {Host: "second"}}; !reflect.DeepEqual(d.Servers, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d.Servers)
+	}
+	// Grown slices propagate through pointers
+	s := []string{"a"}
+	if err := New(&s).Write("-", "b"); err != nil {
+		t.Fatal(err)
+	} else if expected := []string{"a", "b"}; !reflect.DeepEqual(s, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, s)
+	}
+	m := map[string]interface{}{"tags": []interface{}{}}
+	if err := New(&m).Write("tags.-", "web"); err != nil {
+		t.Fatal(err)
+	} else if expected := []interface{}{"web"}; !reflect.DeepEqual(m["tags"], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, m["tags"])
+	}
+}
+
+func TestHas(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"host": "", "port": 0}}
+	c := New(&d)
+	for key, expected := range map[string]bool{
+		"server":      true,
+		"server.host": true,
+		"server.port": true,
+		"server.name": false,
+		"client":      false,
+	} {
+		if v := Has(c, key); v != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, key, v)
+		}
+	}
+	s := Sub(c, "server")
+	if !Has(s, "port") {
+		t.Fatalf("expected %#v, got %#v", true, false)
+	} else if Has(s, "name") {
+		t.Fatalf("expected %#v, got %#v", false, true)
+	}
+}
+
+func TestConfig_ErrorKeyPath(t *testing.T) {
+	type leaf struct {
+		My int
+	}
+	type branch struct {
+		Exotic leaf
+		Name   string
+	}
+	type data struct {
+		Exotic  branch
+		Servers map[string]leaf
+		Items   []leaf
+		Any     interface{}
+	}
+	d := &data{Servers: map[string]leaf{"default": {}}, Items: []leaf{{}}, Any: map[string]interface{}{"port": 80}}
+	c := New(d)
+	for _, test := range []struct {
+		Key      string
+		Path     string
+		Expected KeyError
+	}{
+		{"exotic.exotic.my", "exotic.exotic.my", &ErrIncompatibleType{}},
+		{"servers.default.my", "servers.default.my", &ErrIncompatibleType{}},
+		{"items.0.my", "items.0.my", &ErrIncompatibleType{}},
+		{"exotic.name.first", "exotic.name.first", &ErrUnhandledKind{}},
+		{"any.port.number", "any.port.number", &ErrUnhandledKind{}},
+		{"exotic.missing.my", "exotic.missing", &ErrNoSuchKey{}},
+		{"exotic.exotic.missing", "exotic.exotic.missing", &ErrNoSuchKey{}},
+	} {
+		err := c.Write(test.Key, "value")
+		if reflect.TypeOf(err) != reflect.TypeOf(test.Expected) {
+			t.Fatalf("expected %T error for %#v, got %T error", test.Expected, test.Key, err)
+		} else if k := err.(KeyError).Key(); k != test.Path {
+			t.Fatalf("expected %#v, got %#v", test.Path, k)
+		}
+	}
+}
+
+func TestConfig_NonStringMapKeys(t *testing.T) {
+	type port struct {
+		Name string
+	}
+	type env string
+	type data struct {
+		Ports map[int]port
+		Envs  map[env]string
+		Flags map[bool]string
+	}
+	d := &data{Ports: map[int]port{80: {Name: "http"}}}
+	c := New(d)
+	if v, err := c.ReadString("ports.80.name"); err != nil {
+		t.Fatal(err)
+	} else if v != "http" {
+		t.Fatalf("expected %#v, got %#v", "http", v)
+	}
+	if err := c.Write("ports.8080.name", "proxy"); err != nil {
+		t.Fatal(err)
+	} else if d.Ports[8080].Name != "proxy" {
+		t.Fatalf("expected %#v, got %#v", "proxy", d.Ports[8080].Name)
+	}
+	if err := c.Write("envs.prod", "production"); err != nil {
+		t.Fatal(err)
+	} else if d.Envs["prod"] != "production" {
+		t.Fatalf("expected %#v, got %#v", "production", d.Envs["prod"])
+	}
+	if err := c.Write("flags.true", "on"); err != nil {
+		t.Fatal(err)
+	} else if d.Flags[true] != "on" {
+		t.Fatalf("expected %#v, got %#v", "on", d.Flags[true])
+	}
+	if _, err := c.Read("ports.443"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	for key, expected := range map[string]string{"ports.http.name": "ports.http", "ports.99999999999999999999": "ports.99999999999999999999"} {
+		_, err := c.Read(key)
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if e.Key() != expected {
+			t.Fatalf("expected %#v, got %#v", expected, e.Key())
+		}
+	}
+	err := c.Write("ports.http.name", "web")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "ports.http" {
+		t.Fatalf("expected %#v, got %#v", "ports.http", e.Key())
+	}
+}
+
+func TestNewWithSeparator(t *testing.T) {
+	d := map[string]interface{}{"hosts": map[string]interface{}{"example.com": map[string]interface{}{"port": 443}}}
+	c := NewWithSeparator(&d, "/")
+	if v, err := ReadInt(c, "hosts/example.com/port"); err != nil {
+		t.Fatal(err)
+	} else if v != 443 {
+		t.Fatalf("expected %#v, got %#v", 443, v)
+	}
+	if err := c.Write("hosts/api.example.com/port", 8443); err != nil {
+		t.Fatal(err)
+	} else if v := d["hosts"].(map[string]interface{})["api.example.com"]; !reflect.DeepEqual(v, map[string]interface{}{"port": 8443}) {
+		t.Fatalf("expected %#v, got %#v", map[string]interface{}{"port": 8443}, v)
+	}
+	s := Sub(c, "hosts/example.com")
+	if v, err := ReadInt(s, "port"); err != nil {
+		t.Fatal(err)
+	} else if v != 443 {
+		t.Fatalf("expected %#v, got %#v", 443, v)
+	}
+	if keys, err := s.(Enumerator).Keys(""); err != nil {
+		t.Fatal(err)
+	} else if expected := []string{"port"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+	_, err := c.Read("hosts/example.com/port/number")
+	if e, ok := err.(*ErrUnhandledKind); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "hosts/example.com/port/number" {
+		t.Fatalf("expected %#v, got %#v", "hosts/example.com/port/number", e.Key())
+	}
+}
+
+func TestNewWithSeparator_ElementErrors(t *testing.T) {
+	d := map[string]interface{}{"hosts": map[string]interface{}{"ports": []interface{}{80, "http"}, "labels": map[string]interface{}{"a.b": func() {}}}}
+	c := NewWithSeparator(&d, "/")
+	_, err := ReadIntSlice(c, "hosts/ports")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "hosts/ports/1" {
+		t.Fatalf("expected %#v, got %#v", "hosts/ports/1", e.Key())
+	}
+	_, err = ReadStringMap(c, "hosts/labels")
+	if e, ok := err.(Errors); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e[0].Key() != "hosts/labels/a.b" {
+		t.Fatalf("expected %#v, got %#v", "hosts/labels/a.b", e[0].Key())
+	}
+}
+
+func TestConfig_ReadStringComposite(t *testing.T) {
+	type server struct {
+		Host string `json:"host"`
+	}
+	d := map[string]interface{}{
+		"tags":    []string{"web", "api"},
+		"labels":  map[string]int{"replicas": 3},
+		"ports":   [2]int{80, 443},
+		"server":  server{Host: "localhost"},
+		"payload": []byte("raw"),
+	}
+	c := New(&d)
+	for key, expected := range map[string]string{
+		"tags":    `["web","api"]`,
+		"labels":  `{"replicas":3}`,
+		"ports":   `[80,443]`,
+		"server":  `{"host":"localhost"}`,
+		"payload": "raw",
+	} {
+		if v, err := c.ReadString(key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, key, v)
+		}
+	}
+}
+
+func TestConfig_StructFieldIndex(t *testing.T) {
+	type server struct {
+		Host    string
+		Address string `config:"host"`
+		Port    int    `config:"listen"`
+		Ignored string `config:"-"`
+	}
+	d := server{Host: "localhost", Address: "127.0.0.1", Port: 80}
+	c := New(&d)
+	for key, expected := range map[string]interface{}{"HOST": "localhost", "Listen": 80} {
+		if v, err := c.Read(key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	for _, key := range []string{"port", "ignored", "-"} {
+		if _, err := c.Read(key); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		}
+	}
+}
+
+func TestSubReader(t *testing.T) {
+	d := map[string]interface{}{"profiles": map[string]interface{}{"default": map[string]interface{}{"port": 8080}}}
+	s := SubReader(Layer(New(&d)), "profiles.default")
+	if _, ok := s.(Writer); ok {
+		t.Fatalf("expected %T not to implement Writer", s)
+	}
+	if v, err := ReadInt(s, "port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+	if _, err := s.Read("host"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+// shouting is a Reader upper-casing the strings it reads.
+type shouting struct {
+	Reader
+}
+
+func (s shouting) ReadString(key string) (string, error) {
+	v, err := s.Reader.ReadString(key)
+	return strings.ToUpper(v), err
+}
+
+func TestSubReader_ReadString(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"name": "api", "handler": func() {}}}
+	s := SubReader(shouting{New(&d)}, "server")
+	if v, err := s.ReadString("name"); err != nil {
+		t.Fatal(err)
+	} else if v != "API" {
+		t.Fatalf("expected %#v, got %#v", "API", v)
+	}
+	_, err := s.ReadString("handler")
+	if e, ok := err.(*ErrUnhandledKind); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "server.handler" {
+		t.Fatalf("expected %#v, got %#v", "server.handler", e.Key())
+	}
+}
+
+func TestSub_Nested(t *testing.T) {
+	d := map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": map[string]interface{}{"key": "value"}}}}
+	rw := New(&d)
+	s := Sub(Sub(Sub(rw, "a"), "b"), "c")
+	if n, ok := s.(*sub); !ok {
+		t.Fatalf("expected %T, got %T", n, s)
+	} else if n.Prefix != "a.b.c" || n.RW != rw {
+		t.Fatalf("expected flattened prefix %#v, got %#v", "a.b.c", n.Prefix)
+	}
+	if err := s.Write("key", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := SubReader(Sub(rw, "a"), "b.c").Read("key"); err != nil {
+		t.Fatal(err)
+	} else if v != "other" {
+		t.Fatalf("expected %#v, got %#v", "other", v)
+	}
+}
+
+func TestConfig_ArrayIndexOutOfRange(t *testing.T) {
+	type data struct {
+		Ports [2]int
+	}
+	d := &data{Ports: [2]int{80, 443}}
+	c := New(d)
+	for _, key := range []string{"ports.2", "ports.-1"} {
+		if _, err := c.Read(key); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		} else if e, ok := err.(*ErrIndexOutOfRange); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if e.Key() != key || e.Len != 2 {
+			t.Fatalf("expected %#v of length %#v, got %#v of length %#v", key, 2, e.Key(), e.Len)
+		}
+	}
+	for _, key := range []string{"ports.2", "ports.-"} {
+		if err := c.Write(key, 8080); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		} else if e, ok := err.(*ErrIndexOutOfRange); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if e.Index != 2 {
+			t.Fatalf("expected %#v, got %#v", 2, e.Index)
+		}
+	}
+	if Has(c, "ports.2") {
+		t.Fatalf("expected %#v to be absent", "ports.2")
+	}
+	if err := c.Write("ports.1", 8443); err != nil {
+		t.Fatal(err)
+	} else if expected := [2]int{80, 8443}; d.Ports != expected {
+		t.Fatalf("expected %#v, got %#v", expected, d.Ports)
+	}
+}
+
+func TestReadStringMap(t *testing.T) {
+	d := map[string]interface{}{
+		"labels": map[string]string{"app": "api", "tier": "web"},
+		"ports":  map[int]interface{}{80: "http", 443: 443},
+		"name":   "api",
+	}
+	c := New(&d)
+	if v, err := ReadStringMap(c, "labels"); err != nil {
+		t.Fatal(err)
+	} else if expected := map[string]string{"app": "api", "tier": "web"}; !reflect.DeepEqual(v, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, v)
+	}
+	if v, err := ReadStringMap(c, "ports"); err != nil {
+		t.Fatal(err)
+	} else if expected := map[string]string{"80": "http", "443": "443"}; !reflect.DeepEqual(v, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, v)
+	}
+	_, err := ReadStringMap(c, "name")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "name" {
+		t.Fatalf("expected %#v, got %#v", "name", e.Key())
+	}
+}
+
+func TestConfig_WriteMapOfStructs(t *testing.T) {
+	type inner struct {
+		Sub string
+	}
+	type outer struct {
+		Field inner
+		Ptr   *inner
+	}
+	type data struct {
+		M map[string]outer
+	}
+	d := &data{M: map[string]outer{"key": {Ptr: &inner{}}}}
+	c := New(d)
+	if err := c.Write("m.key.field.sub", "value"); err != nil {
+		t.Fatal(err)
+	} else if v := d.M["key"].Field.Sub; v != "value" {
+		t.Fatalf("expected %#v, got %#v", "value", v)
+	}
+	if err := c.Write("m.key.ptr.sub", "pointed"); err != nil {
+		t.Fatal(err)
+	} else if v := d.M["key"].Ptr.Sub; v != "pointed" {
+		t.Fatalf("expected %#v, got %#v", "pointed", v)
+	}
+	if err := c.Write("m.other.field.sub", "created"); err != nil {
+		t.Fatal(err)
+	} else if v := d.M["other"].Field.Sub; v != "created" {
+		t.Fatalf("expected %#v, got %#v", "created", v)
+	}
+	m := map[string]map[string]outer{"a": {"b": {}}}
+	if err := New(&m).Write("a.b.field.sub", "nested"); err != nil {
+		t.Fatal(err)
+	} else if v := m["a"]["b"].Field.Sub; v != "nested" {
+		t.Fatalf("expected %#v, got %#v", "nested", v)
+	}
+	i := map[string]interface{}{"key": outer{}}
+	if err := New(&i).Write("key.field.sub", "held"); err != nil {
+		t.Fatal(err)
+	} else if v := i["key"].(outer).Field.Sub; v != "held" {
+		t.Fatalf("expected %#v, got %#v", "held", v)
+	}
+}
+
+func TestConfig_WriteNilPointerToMapSlice(t *testing.T) {
+	type data struct {
+		Labels *map[string]string
+		Hosts  *[]string
+		Ports  *map[string]*[]int
+	}
+	d := &data{}
+	c := New(d)
+	if err := c.Write("labels.app", "api"); err != nil {
+		t.Fatal(err)
+	} else if d.Labels == nil || (*d.Labels)["app"] != "api" {
+		t.Fatalf("expected %#v, got %#v", "api", d.Labels)
+	}
+	if err := c.Write("hosts.-", "localhost"); err != nil {
+		t.Fatal(err)
+	} else if expected := []string{"localhost"}; d.Hosts == nil || !reflect.DeepEqual(*d.Hosts, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d.Hosts)
+	}
+	if err := c.Write("ports.http.-", 80); err != nil {
+		t.Fatal(err)
+	} else if expected := []int{80}; d.Ports == nil || !reflect.DeepEqual(*(*d.Ports)["http"], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d.Ports)
+	}
+}
+
+func TestNewStrict(t *testing.T) {
+	type data struct {
+		Port   int
+		Small  int8
+		Count  uint
+		Ratio  float64
+		Offset int64
+	}
+	d := &data{}
+	c := NewStrict(d)
+	for key, value := range map[string]interface{}{"port": 8080.0, "small": 100, "count": 3, "ratio": 1, "offset": int32(-5)} {
+		if err := c.Write(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if expected := (&data{Port: 8080, Small: 100, Count: 3, Ratio: 1, Offset: -5}); !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+	for key, value := range map[string]interface{}{"port": 80.5, "small": 300, "count": -1, "offset": uint64(1 << 63)} {
+		err := c.Write(key, value)
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error for %#v, got %T error", e, key, err)
+		} else if e.Key() != key {
+			t.Fatalf("expected %#v, got %#v", key, e.Key())
+		}
+	}
+	// Lax configurations keep truncating
+	if err := New(d).Write("port", 80.5); err != nil {
+		t.Fatal(err)
+	} else if d.Port != 80 {
+		t.Fatalf("expected %#v, got %#v", 80, d.Port)
+	}
+}
+
+func TestConfig_WriteNilInterface(t *testing.T) {
+	type server struct {
+		Host string
+	}
+	type data struct {
+		Typed    interface{}
+		Free     interface{}
+		Stringer fmt.Stringer
+	}
+	d := &data{Typed: (*server)(nil)}
+	c := New(d)
+	if err := c.Write("typed.host", "localhost"); err != nil {
+		t.Fatal(err)
+	} else if s, ok := d.Typed.(*server); !ok || s == nil || s.Host != "localhost" {
+		t.Fatalf("expected %#v, got %#v", &server{Host: "localhost"}, d.Typed)
+	}
+	if err := c.Write("free.a.b", 1); err != nil {
+		t.Fatal(err)
+	} else if expected := map[string]interface{}{"a": map[string]interface{}{"b": 1}}; !reflect.DeepEqual(d.Free, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d.Free)
+	}
+	err := c.Write("stringer.name", "value")
+	if e, ok := err.(*ErrUnhandledKind); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "stringer.name" || e.Kind != "interface" {
+		t.Fatalf("expected %#v of kind %#v, got %#v of kind %#v", "stringer.name", "interface", e.Key(), e.Kind)
+	}
+}
+
+func TestNewCaseSensitive(t *testing.T) {
+	type data struct {
+		Name string
+		Port int `config:"port"`
+		IDs  map[string]int
+	}
+	d := &data{Name: "api", Port: 80, IDs: map[string]int{"ID": 1, "id": 2}}
+	c := NewCaseSensitive(d)
+	for key, expected := range map[string]interface{}{"Name": "api", "port": 80, "IDs.ID": 1, "IDs.id": 2} {
+		if v, err := c.Read(key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	for _, key := range []string{"name", "Port", "ids.id", "IDs.Id"} {
+		if _, err := c.Read(key); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		}
+	}
+	if err := c.Write("IDs.Id", 3); err != nil {
+		t.Fatal(err)
+	} else if expected := map[string]int{"ID": 1, "id": 2, "Id": 3}; !reflect.DeepEqual(d.IDs, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d.IDs)
+	}
+	// Default configurations keep matching case-insensitively
+	if v, err := New(d).Read("NAME"); err != nil {
+		t.Fatal(err)
+	} else if v != "api" {
+		t.Fatalf("expected %#v, got %#v", "api", v)
+	}
+}
+
+func TestNewCaseSensitive_Keys(t *testing.T) {
+	type data struct {
+		Name string
+		Port int `config:"port"`
+	}
+	c := NewCaseSensitive(&data{Name: "api", Port: 80})
+	if keys, err := c.(Enumerator).Keys(""); err != nil {
+		t.Fatal(err)
+	} else if expected := []string{"Name", "port"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+	d := &data{}
+	if err := Merge(NewCaseSensitive(d), c); err != nil {
+		t.Fatal(err)
+	} else if expected := (&data{Name: "api", Port: 80}); !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+}
+
+// level is an enumeration formatted by its name.
+type level int
+
+func (l level) String() string {
+	return [...]string{"debug", "info", "error"}[l]
+}
+
+// mode is an enumeration marshaled by its name.
+type mode uint8
+
+func (m mode) MarshalText() ([]byte, error) {
+	if m > 1 {
+		return nil, fmt.Errorf("unknown mode %d", uint8(m))
+	}
+	return []byte([...]string{"passive", "active"}[m]), nil
+}
+
+func TestConfig_ReadStringFormatted(t *testing.T) {
+	d := map[string]interface{}{
+		"level":   level(1),
+		"mode":    mode(1),
+		"broken":  mode(5),
+		"weight":  uint8(65),
+		"short":   int16(-3),
+		"started": time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	c := New(&d)
+	for key, expected := range map[string]string{"level": "info", "mode": "active", "weight": "65", "short": "-3", "started": "2021-01-02T03:04:05Z"} {
+		if v, err := c.ReadString(key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	_, err := c.ReadString("broken")
+	if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "broken" {
+		t.Fatalf("expected %#v, got %#v", "broken", e.Key())
+	}
+}
+
+func (m *mode) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "passive":
+		*m = 0
+	case "active":
+		*m = 1
+	default:
+		return fmt.Errorf("unknown mode %q", b)
+	}
+	return nil
+}
+
+func TestConfig_WriteTextUnmarshaler(t *testing.T) {
+	type data struct {
+		Mode  mode
+		Modes map[string]*mode
+	}
+	d := &data{Modes: map[string]*mode{}}
+	c := New(d)
+	if err := c.Write("mode", "active"); err != nil {
+		t.Fatal(err)
+	} else if d.Mode != 1 {
+		t.Fatalf("expected %#v, got %#v", mode(1), d.Mode)
+	}
+	if err := c.Write("modes.backup", "passive"); err != nil {
+		t.Fatal(err)
+	} else if m := d.Modes["backup"]; m == nil || *m != 0 {
+		t.Fatalf("expected %#v, got %#v", mode(0), m)
+	}
+	// Values round-trip through ReadString
+	if v, err := c.ReadString("mode"); err != nil {
+		t.Fatal(err)
+	} else if v != "active" {
+		t.Fatalf("expected %#v, got %#v", "active", v)
+	}
+	err := c.Write("mode", "unknown")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "mode" {
+		t.Fatalf("expected %#v, got %#v", "mode", e.Key())
+	}
+}
+
+func TestStrip(t *testing.T) {
+	d := map[string]interface{}{"profiles": map[string]interface{}{"default": map[string]interface{}{"port": 80}}}
+	s := Strip(New(&d), "profiles.default")
+	if err := s.Write("port", 8080); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := ReadInt(s, "port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+	for _, key := range []string{"profiles.default.port", "Profiles.Default", "PROFILES.DEFAULT.PORT"} {
+		if _, err := ReadInt(s, key); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		} else if e, ok := err.(*ErrAbsoluteKey); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if e.Key() != key {
+			t.Fatalf("expected %#v, got %#v", key, e.Key())
+		}
+		if err := s.Write(key, 443); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		}
+	}
+}
+
+func TestStrip_CaseSensitive(t *testing.T) {
+	d := map[string]interface{}{"db": map[string]interface{}{"DB": map[string]interface{}{"x": 1}, "x": 2}}
+	s := Strip(NewCaseSensitive(&d), "db")
+	if v, err := ReadInt(s, "DB.x"); err != nil {
+		t.Fatal(err)
+	} else if v != 1 {
+		t.Fatalf("expected %#v, got %#v", 1, v)
+	}
+	if _, err := ReadInt(s, "db.x"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrAbsoluteKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	path, err := Sub(NewCaseSensitive(&d), "db").(PathReader).ReadPath("DB.x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []PathValue{{Key: "DB", Value: map[string]interface{}{"x": 1}}, {Key: "DB.x", Value: 1}}
+	if !reflect.DeepEqual(path, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, path)
+	}
+}
+
+func TestConfig_WriteInterfaceHeldComposites(t *testing.T) {
+	type data struct {
+		Free  interface{}
+		Hosts interface{}
+	}
+	d := &data{
+		Free:  map[string]interface{}{"list": []interface{}{"a", "b"}, "names": []string{"x"}},
+		Hosts: []string{"localhost"},
+	}
+	c := New(d)
+	for key, value := range map[string]interface{}{"free.list.0": "changed", "free.names.-": "y", "hosts.0": "remote", "free.nested.key": 1} {
+		if err := c.Write(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := &data{
+		Free:  map[string]interface{}{"list": []interface{}{"changed", "b"}, "names": []string{"x", "y"}, "nested": map[string]interface{}{"key": 1}},
+		Hosts: []string{"remote"},
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+}
+
+func TestReadMany(t *testing.T) {
+	d := map[string]interface{}{"name": "api", "server": map[string]interface{}{"port": 8080}}
+	c := New(&d)
+	values, errs := ReadMany(c, "name", "server.port", "server.host")
+	if expected := map[string]interface{}{"name": "api", "server.port": 8080}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, values)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected %#v errors, got %#v", 1, len(errs))
+	} else if e, ok := errs["server.host"].(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, errs["server.host"])
+	}
+	if _, errs := ReadMany(c, "name"); errs != nil {
+		t.Fatalf("unexpected %#v errors", errs)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+)
+
+// ContextReader abstracts a readable configuration honoring contexts, such as one backed by a remote store.
+type ContextReader interface {
+	// ReadContext gets a key's value, aborting with an error once the context is done.
+	ReadContext(ctx context.Context, key string) (interface{}, error)
+}
+
+// ContextWriter abstracts a writable configuration honoring contexts, such as one backed by a remote store.
+type ContextWriter interface {
+	// WriteContext sets a key's value, aborting with an error once the context is done.
+	WriteContext(ctx context.Context, key string, v interface{}) error
+}
+
+// ContextReadWriter abstracts a readable and writable configuration honoring contexts.
+type ContextReadWriter interface {
+	ContextReader
+	ContextWriter
+}
+
+// ReadContext is a prefixed wrapper around the ContextReader. Readers not honoring contexts are only read if the
+// context isn't done yet.
+func (s *subReader) ReadContext(ctx context.Context, key string) (interface{}, error) {
+	if r, ok := s.R.(ContextReader); ok {
+		return r.ReadContext(ctx, s.resolve(key))
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, &ErrCanceled{Err: err, ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	return s.R.Read(s.resolve(key))
+}
+
+// WriteContext is a prefixed wrapper around the ContextWriter. Writers not honoring contexts are only written if the
+// context isn't done yet.
+func (s *sub) WriteContext(ctx context.Context, key string, v interface{}) error {
+	if w, ok := s.RW.(ContextWriter); ok {
+		return w.WriteContext(ctx, s.resolve(key), v)
+	}
+	if err := ctx.Err(); err != nil {
+		return &ErrCanceled{Err: err, ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	return s.RW.Write(s.resolve(key), v)
+}
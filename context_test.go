@@ -0,0 +1,91 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// countdown is a context canceled after its Err method has been called a number of times.
+type countdown struct {
+	context.Context
+	Remaining int
+}
+
+func (c *countdown) Err() error {
+	if c.Remaining <= 0 {
+		return context.Canceled
+	}
+	c.Remaining--
+	return nil
+}
+
+func TestConfig_ReadContextCanceled(t *testing.T) {
+	d := map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": map[string]interface{}{"d": "deep"}}}}
+	c := New(&d).(interface {
+		ReadContext(ctx context.Context, key string) (interface{}, error)
+	})
+	if v, err := c.ReadContext(context.Background(), "a.b.c.d"); err != nil {
+		t.Fatal(err)
+	} else if v != "deep" {
+		t.Fatalf("expected %#v, got %#v", "deep", v)
+	}
+	_, err := c.ReadContext(&countdown{Context: context.Background(), Remaining: 4}, "a.b.c.d")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %#v, got %#v", context.Canceled, err)
+	} else if e, ok := err.(*ErrCanceled); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "a.b" {
+		t.Fatalf("expected %#v, got %#v", "a.b", e.Key())
+	}
+}
+
+func TestConfig_WriteContextCanceled(t *testing.T) {
+	d := map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": "deep"}}}
+	c := New(&d).(interface {
+		WriteContext(ctx context.Context, key string, v interface{}) error
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.WriteContext(ctx, "a.b.c", "changed"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected %#v, got %#v", context.Canceled, err)
+	} else if v := d["a"].(map[string]interface{})["b"].(map[string]interface{})["c"]; v != "deep" {
+		t.Fatalf("expected %#v, got %#v", "deep", v)
+	}
+}
+
+// implementsContext reports whether a ReadWriter honors contexts.
+func implementsContext(rw ReadWriter) bool {
+	_, ok := rw.(ContextReadWriter)
+	return ok
+}
+
+func TestSub_Context(t *testing.T) {
+	d := map[string]interface{}{"profiles": map[string]interface{}{"default": map[string]interface{}{"port": 80}}}
+	for _, rw := range []ReadWriter{New(&d), Synchronized(New(&d))} {
+		s := Sub(rw, "profiles.default").(ContextReadWriter)
+		if err := s.WriteContext(context.Background(), "port", 8080); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := s.ReadContext(context.Background(), "port"); err != nil {
+			t.Fatal(err)
+		} else if v != 8080 {
+			t.Fatalf("expected %#v, got %#v", 8080, v)
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := s.ReadContext(ctx, "port"); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected %#v, got %#v", context.Canceled, err)
+		}
+		if err := s.WriteContext(ctx, "port", 443); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected %#v, got %#v", context.Canceled, err)
+		}
+	}
+	if c := New(&d); !implementsContext(c) {
+		t.Fatalf("expected %T to implement ContextReadWriter", c)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding"
+	"encoding/base64"
+	"reflect"
+)
+
+// textUnmarshaler is the type of encoding.TextUnmarshaler, whose implementations can be written from strings.
+var textUnmarshaler = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+// convert converts a written value into the type t of its destination.
+// Strings written into URLs are parsed while those written into encoding.TextUnmarshaler implementations,
+// such as net.IP, are unmarshaled rather than cast. Strings written into byte slices are cast unless base64 decoding
+// is enabled.
+// Pointer destinations are allocated to hold the converted value.
+// Without coercion, only values assignable to t are accepted, while strict numbers reject lossy integer conversions.
+func (c *config) convert(v reflect.Value, t reflect.Type) (reflect.Value, KeyError) {
+	if !v.IsValid() {
+		return reflect.Zero(t), nil
+	}
+	if v.Type() == t {
+		return v, nil
+	}
+	if c.Options.noCoercion {
+		if !v.Type().AssignableTo(t) {
+			return v, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+		}
+		return v, nil
+	}
+	if v.Kind() == reflect.String {
+		if t == urlType || t == reflect.PtrTo(urlType) {
+			u, err := toURL(v.String())
+			if err != nil {
+				return v, err
+			}
+			if t == urlType {
+				return reflect.ValueOf(u).Elem(), nil
+			}
+			return reflect.ValueOf(u), nil
+		}
+		if n, ok := unmarshalText(v.String(), t); ok {
+			return n, nil
+		} else if n.IsValid() {
+			return v, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+		}
+		if c.Options.base64 && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			b, err := base64.StdEncoding.DecodeString(v.String())
+			if err != nil {
+				return v, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+			}
+			return reflect.ValueOf(b).Convert(t), nil
+		}
+	}
+	if !v.CanConvert(t) {
+		// Allocate pointers to the converted value
+		if t.Kind() == reflect.Ptr {
+			e, err := c.convert(v, t.Elem())
+			if err != nil {
+				return v, err
+			}
+			p := reflect.New(t.Elem())
+			p.Elem().Set(e)
+			return p, nil
+		}
+		return v, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+	}
+	n := v.Convert(t)
+	if c.Options.strictNumbers && lossy(v, n) {
+		return v, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+	}
+	return n, nil
+}
+
+// unmarshalText creates a value of type t from a string if t, or the type it points to, implements encoding.TextUnmarshaler.
+// The returned value is only valid if t implements encoding.TextUnmarshaler, in which case the boolean reports success.
+func unmarshalText(s string, t reflect.Type) (reflect.Value, bool) {
+	switch {
+	case reflect.PtrTo(t).Implements(textUnmarshaler):
+		n := reflect.New(t)
+		err := n.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		return n.Elem(), err == nil
+	case t.Kind() == reflect.Ptr && t.Implements(textUnmarshaler):
+		n := reflect.New(t.Elem())
+		err := n.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		return n, err == nil
+	default:
+		return reflect.Value{}, false
+	}
+}
+
+// prototype creates a new value of type t, initialized from a copy of the WithPrototype struct if its type matches t
+// or the type t points to.
+func (c *config) prototype(t reflect.Type) reflect.Value {
+	e := reflect.New(t).Elem()
+	if c.Options.prototype == nil {
+		return e
+	}
+	p := indirect(reflect.ValueOf(c.Options.prototype()))
+	if p.Kind() != reflect.Struct {
+		return e
+	}
+	p = deepCopy(p, false)
+	switch {
+	case p.Type() == t:
+		e.Set(p)
+	case t.Kind() == reflect.Ptr && p.Type() == t.Elem():
+		n := reflect.New(t.Elem())
+		n.Elem().Set(p)
+		e.Set(n)
+	}
+	return e
+}
+
+// lossy reports whether converting a number v into the integer n lost information, such as a fractional part.
+func lossy(v reflect.Value, n reflect.Value) bool {
+	if !isInteger(n.Kind()) || !isInteger(v.Kind()) && v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+		return false
+	}
+	// Sign changes are lost through unsigned conversions while truncations don't survive the round-trip
+	if isNegative(v) != isNegative(n) {
+		return true
+	}
+	return v.Interface() != n.Convert(v.Type()).Interface()
+}
+
+// isInteger reports whether a kind is a signed or unsigned integer.
+func isInteger(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// isNegative reports whether a numeric value is negative.
+func isNegative(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() < 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() < 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+)
+
+// mask is the value replacing secret strings when redacting.
+const mask = "***"
+
+// deepCopy recursively copies an element so that the copy shares no map, slice or pointer with the original.
+// When redact is set, struct fields tagged as secret are masked in the copy.
+// Unexported struct fields can't be set through reflection and are therefore shallow-copied.
+func deepCopy(element reflect.Value, redact bool) reflect.Value {
+	switch element.Kind() {
+	case reflect.Interface:
+		if element.IsNil() {
+			return element
+		}
+		n := reflect.New(element.Type()).Elem()
+		n.Set(deepCopy(element.Elem(), redact))
+		return n
+	case reflect.Ptr:
+		if element.IsNil() {
+			return element
+		}
+		p := reflect.New(element.Type().Elem())
+		p.Elem().Set(deepCopy(element.Elem(), redact))
+		return p
+	case reflect.Struct:
+		n := reflect.New(element.Type()).Elem()
+		n.Set(element)
+		t := element.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+			if redact && secret(f) {
+				n.Field(i).Set(masked(f.Type))
+				continue
+			}
+			n.Field(i).Set(deepCopy(element.Field(i), redact))
+		}
+		return n
+	case reflect.Map:
+		if element.IsNil() {
+			return element
+		}
+		n := reflect.MakeMapWithSize(element.Type(), element.Len())
+		i := element.MapRange()
+		for i.Next() {
+			n.SetMapIndex(i.Key(), deepCopy(i.Value(), redact))
+		}
+		return n
+	case reflect.Slice:
+		if element.IsNil() {
+			return element
+		}
+		n := reflect.MakeSlice(element.Type(), element.Len(), element.Len())
+		for i := 0; i < element.Len(); i++ {
+			n.Index(i).Set(deepCopy(element.Index(i), redact))
+		}
+		return n
+	case reflect.Array:
+		n := reflect.New(element.Type()).Elem()
+		for i := 0; i < element.Len(); i++ {
+			n.Index(i).Set(deepCopy(element.Index(i), redact))
+		}
+		return n
+	default:
+		return element
+	}
+}
+
+// masked returns the redacted value of a type.
+// Strings and interfaces are replaced by the mask while other types are reset to their zero value.
+func masked(t reflect.Type) reflect.Value {
+	m := reflect.ValueOf(mask)
+	switch t.Kind() {
+	case reflect.String:
+		return m.Convert(t)
+	case reflect.Interface:
+		if m.Type().Implements(t) {
+			n := reflect.New(t).Elem()
+			n.Set(m)
+			return n
+		}
+	}
+	return reflect.Zero(t)
+}
+
+// Cloner abstracts a configuration able to create independent copies of itself.
+type Cloner interface {
+	// Clone returns a deep copy of the configuration, such that writes on either don't affect the other.
+	Clone() ReadWriter
+}
+
+// Clone returns a deep copy of the configuration, such that writes on either don't affect the other.
+// The copy shares the options of the original configuration.
+func (c *config) Clone() ReadWriter {
+	n := &config{Data: deepCopy(reflect.ValueOf(c.Data), false).Interface(), Options: c.Options}
+	n.accessor = accessor{n.Read}
+	return n
+}
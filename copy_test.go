@@ -0,0 +1,48 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Clone(t *testing.T) {
+	type server struct {
+		Host string
+		Tags []string
+	}
+	type data struct {
+		Servers map[string]*server
+		Labels  map[string]interface{}
+	}
+	d := &data{
+		Servers: map[string]*server{"default": {Host: "localhost", Tags: []string{"web"}}},
+		Labels:  map[string]interface{}{"env": "dev"},
+	}
+	c := New(d).(Cloner).Clone()
+	for key, value := range map[string]interface{}{
+		"servers.default.host":   "example.com",
+		"servers.default.tags.0": "api",
+		"servers.backup.host":    "remote",
+		"labels.env":             "prod",
+	} {
+		if err := c.Write(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := &data{
+		Servers: map[string]*server{"default": {Host: "localhost", Tags: []string{"web"}}},
+		Labels:  map[string]interface{}{"env": "dev"},
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+	if v, err := c.ReadString("servers.default.tags.0"); err != nil {
+		t.Fatal(err)
+	} else if v != "api" {
+		t.Fatalf("expected %#v, got %#v", "api", v)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// flagValue is the type of flag.Value, whose implementations are set from strings.
+var flagValue = reflect.TypeOf((*flag.Value)(nil)).Elem()
+
+// UnmarshalMap populates the map pointed to by out with the map at key in the Reader, converting each of its values.
+//
+// UnmarshalMap allows for plugin-registry shapes where a `map[string]interface{}` is read into a `map[string]Plugin`.
+// Entries are merged into the existing map, leaving entries absent from the configuration in place, and are copied
+// such that modifying the map leaves the configuration untouched.
+func UnmarshalMap(r Reader, key string, out interface{}) error {
+	o := reflect.ValueOf(out)
+	if o.Kind() != reflect.Ptr || o.IsNil() || o.Elem().Kind() != reflect.Map {
+		return &ErrIncompatibleType{Type: fmt.Sprintf("%T", out), ConfigurationError: &ConfigurationError{key}}
+	}
+	v, err := r.Read(key)
+	if err != nil {
+		return err
+	}
+	src := indirect(reflect.ValueOf(v))
+	if src.Kind() != reflect.Map {
+		return &ErrIncompatibleType{Type: o.Elem().Type().String(), ConfigurationError: &ConfigurationError{key}}
+	}
+	// Merge entry by entry into the caller's map rather than aliasing the configuration's
+	if kerr := decodeMap(o.Elem(), deepCopy(src, false)); kerr != nil {
+		kerr.From(key)
+		return kerr
+	}
+	return nil
+}
+
+// ReadInto copies the sub-configuration of the Reader at key into the value pointed to by target, converting along
+// the way. Struct fields are matched by tag or name like Read does, values which can't be converted resulting in an
+// ErrIncompatibleType. Targets implementing flag.Value are provided the ReadString representation through their Set
+// method. Maps, slices and pointers are copied, such that modifying the target leaves the configuration untouched.
+func ReadInto(r Reader, key string, target interface{}) error {
+	t := reflect.ValueOf(target)
+	if t.Kind() != reflect.Ptr || t.IsNil() {
+		return &ErrIncompatibleType{Type: fmt.Sprintf("%T", target), ConfigurationError: &ConfigurationError{key}}
+	}
+	v, err := r.Read(key)
+	if err != nil {
+		return err
+	}
+	if kerr := decode(t.Elem(), deepCopy(reflect.ValueOf(v), false)); kerr != nil {
+		kerr.From(key)
+		return kerr
+	}
+	return nil
+}
+
+// indirect follows interfaces and pointers until reaching a concrete element.
+func indirect(element reflect.Value) reflect.Value {
+	for element.Kind() == reflect.Interface || element.Kind() == reflect.Ptr {
+		if element.IsNil() {
+			return reflect.Value{}
+		}
+		element = element.Elem()
+	}
+	return element
+}
+
+// decode recursively assigns the source element to the settable destination element, converting along the way.
+// Maps and structs are decoded into structs by matching their keys with the destination fields.
+func decode(dst reflect.Value, src reflect.Value) KeyError {
+	// Unwrap the source
+	for src.Kind() == reflect.Interface || src.Kind() == reflect.Ptr {
+		if src.Type().AssignableTo(dst.Type()) {
+			dst.Set(src)
+			return nil
+		}
+		if src.IsNil() {
+			return nil
+		}
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		return nil
+	}
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	// Set flag values from their string representation
+	if dst.CanAddr() && dst.Addr().Type().Implements(flagValue) {
+		s, err := toString(src.Interface())
+		if err != nil {
+			return err
+		}
+		if err := dst.Addr().Interface().(flag.Value).Set(s); err != nil {
+			return &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+		}
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Ptr:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return decode(dst.Elem(), src)
+	case reflect.Struct:
+		t := dst.Type()
+		switch src.Kind() {
+		case reflect.Map:
+			i := src.MapRange()
+			for i.Next() {
+				name := fmt.Sprint(i.Key().Interface())
+				if f, ok := structField(t, name, false); ok {
+					if err := decode(dst.Field(f), i.Value()); err != nil {
+						err.From(name)
+						return err
+					}
+				}
+			}
+			return nil
+		case reflect.Struct:
+			s := src.Type()
+			for i := 0; i < s.NumField(); i++ {
+				name, ok := fieldKey(s.Field(i))
+				if s.Field(i).PkgPath != "" || !ok {
+					continue
+				}
+				if f, ok := structField(t, name, false); ok {
+					if err := decode(dst.Field(f), src.Field(i)); err != nil {
+						err.From(name)
+						return err
+					}
+				}
+			}
+			return nil
+		}
+	case reflect.Map:
+		if src.Kind() != reflect.Map {
+			break
+		}
+		return decodeMap(dst, src)
+	case reflect.Slice:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			break
+		}
+		n := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := decode(n.Index(i), src.Index(i)); err != nil {
+				err.From(strconv.Itoa(i))
+				return err
+			}
+		}
+		dst.Set(n)
+		return nil
+	}
+	// Format strings rather than casting them
+	if dst.Kind() == reflect.String && src.Kind() != reflect.String {
+		s, err := toString(src.Interface())
+		if err != nil {
+			return err
+		}
+		dst.SetString(s)
+		return nil
+	}
+	// Attempt conversion otherwise
+	if src.CanConvert(dst.Type()) {
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+	}
+	return &ErrIncompatibleType{Type: dst.Type().String(), ConfigurationError: &ConfigurationError{}}
+}
+
+// decodeMap decodes each entry of the source map into the settable destination map, allocating it if nil.
+// Existing destination entries are kept unless overwritten by a source entry.
+func decodeMap(dst reflect.Value, src reflect.Value) KeyError {
+	if dst.IsNil() {
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+	}
+	kt := dst.Type().Key()
+	et := dst.Type().Elem()
+	i := src.MapRange()
+	for i.Next() {
+		name := fmt.Sprint(i.Key().Interface())
+		k := reflect.New(kt).Elem()
+		if err := decode(k, i.Key()); err != nil {
+			err.From(name)
+			return err
+		}
+		e := reflect.New(et).Elem()
+		if err := decode(e, i.Value()); err != nil {
+			err.From(name)
+			return err
+		}
+		dst.SetMapIndex(k, e)
+	}
+	return nil
+}
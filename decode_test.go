@@ -0,0 +1,230 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUnmarshalMap(t *testing.T) {
+	type plugin struct {
+		Path    string
+		Enabled bool
+		Workers int
+	}
+	d := map[string]interface{}{
+		"plugins": map[string]interface{}{
+			"auth":  map[string]interface{}{"path": "/usr/lib/auth.so", "enabled": true, "workers": 2.0},
+			"cache": map[string]interface{}{"path": "/usr/lib/cache.so"},
+		},
+	}
+	c := New(&d)
+	plugins := map[string]plugin{}
+	if err := UnmarshalMap(c, "plugins", &plugins); err != nil {
+		t.Fatal(err)
+	}
+	if len(plugins) != 2 {
+		t.Fatalf("expected %#v plugins, got %#v", 2, len(plugins))
+	} else if p := plugins["auth"]; p.Path != "/usr/lib/auth.so" || !p.Enabled || p.Workers != 2 {
+		t.Fatalf("unexpected %#v plugin", p)
+	} else if p := plugins["cache"]; p.Path != "/usr/lib/cache.so" || p.Enabled {
+		t.Fatalf("unexpected %#v plugin", p)
+	}
+}
+
+// static is a minimal Reader implementation serving fixed values.
+type static map[string]interface{}
+
+func (s static) Read(key string) (interface{}, error) {
+	if v, ok := s[key]; ok {
+		return v, nil
+	}
+	return nil, &ErrNoSuchKey{&ConfigurationError{key}}
+}
+
+func (s static) ReadString(key string) (string, error) {
+	v, err := s.Read(key)
+	if err != nil {
+		return "", err
+	}
+	str, kerr := toString(v)
+	if kerr != nil {
+		kerr.From(key)
+		return "", kerr
+	}
+	return str, nil
+}
+
+func TestUnmarshalMap_Merge(t *testing.T) {
+	d := map[string]interface{}{
+		"limits": map[string]interface{}{"conns": 100, "hosts": []interface{}{"a"}},
+	}
+	c := New(&d)
+	limits := map[string]interface{}{"timeout": 30}
+	if err := UnmarshalMap(c, "limits", &limits); err != nil {
+		t.Fatal(err)
+	}
+	if len(limits) != 3 || limits["timeout"] != 30 || limits["conns"] != 100 {
+		t.Fatalf("unexpected %#v limits", limits)
+	}
+	limits["conns"] = 1
+	limits["hosts"].([]interface{})[0] = "b"
+	if v, err := c.Read("limits.conns"); err != nil {
+		t.Fatal(err)
+	} else if v != 100 {
+		t.Fatalf("expected %#v, got %#v", 100, v)
+	}
+	if v, err := c.Read("limits.hosts.0"); err != nil {
+		t.Fatal(err)
+	} else if v != "a" {
+		t.Fatalf("expected %#v, got %#v", "a", v)
+	}
+	if _, err := c.Read("limits.timeout"); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+func TestUnmarshalMap_Reader(t *testing.T) {
+	r := static{"limits": map[string]interface{}{"conns": 100.0}}
+	limits := map[string]int{}
+	if err := UnmarshalMap(r, "limits", &limits); err != nil {
+		t.Fatal(err)
+	} else if limits["conns"] != 100 {
+		t.Fatalf("expected %#v, got %#v", 100, limits["conns"])
+	}
+}
+
+func TestConfig_UnmarshalMapIncompatible(t *testing.T) {
+	type plugin struct {
+		Workers int
+	}
+	d := map[string]interface{}{
+		"plugins": map[string]interface{}{
+			"auth": map[string]interface{}{"workers": []string{"many"}},
+		},
+	}
+	c := New(&d)
+	plugins := map[string]plugin{}
+	err := UnmarshalMap(c, "plugins", &plugins)
+	if err == nil {
+		t.Fatal("expected error but got none")
+	}
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if key := "plugins.auth.workers"; e.Key() != key {
+		t.Fatalf("expected %#v, got %#v", key, e.Key())
+	}
+}
+
+func TestConfig_UnmarshalMapNotMap(t *testing.T) {
+	d := map[string]interface{}{"plugins": "none"}
+	c := New(&d)
+	plugins := map[string]struct{}{}
+	if err := UnmarshalMap(c, "plugins", &plugins); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+// list is a comma-separated flag.Value.
+type list []string
+
+func (l *list) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *list) Set(s string) error {
+	if s == "" {
+		return errors.New("empty list")
+	}
+	*l = strings.Split(s, ",")
+	return nil
+}
+
+func TestConfig_ReadIntoFlagValue(t *testing.T) {
+	d := map[string]interface{}{"hosts": "foo,bar", "empty": ""}
+	c := New(&d)
+	var l list
+	if err := ReadInto(c, "hosts", &l); err != nil {
+		t.Fatal(err)
+	} else if expected := (list{"foo", "bar"}); !reflect.DeepEqual(l, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, l)
+	}
+	if err := ReadInto(c, "empty", &l); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "empty" {
+		t.Fatalf("expected %#v, got %#v", "empty", e.Key())
+	}
+}
+
+func TestConfig_ReadIntoStruct(t *testing.T) {
+	type database struct {
+		Host  string
+		Port  int
+		Hosts list
+	}
+	d := map[string]interface{}{"database": map[string]interface{}{"host": "localhost", "port": 5432, "hosts": "a,b"}}
+	c := New(&d)
+	db := database{}
+	if err := ReadInto(c, "database", &db); err != nil {
+		t.Fatal(err)
+	} else if expected := (database{Host: "localhost", Port: 5432, Hosts: list{"a", "b"}}); !reflect.DeepEqual(db, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, db)
+	}
+}
+
+func TestConfig_ReadIntoCopy(t *testing.T) {
+	type server struct {
+		Labels map[string]string
+		Ports  []int
+	}
+	d := map[string]interface{}{"server": &server{Labels: map[string]string{"env": "prod"}, Ports: []int{80}}}
+	c := New(&d)
+	var s *server
+	if err := ReadInto(c, "server", &s); err != nil {
+		t.Fatal(err)
+	}
+	s.Labels["env"] = "dev"
+	s.Ports[0] = 8080
+	if o := d["server"].(*server); o == s || o.Labels["env"] != "prod" || o.Ports[0] != 80 {
+		t.Fatalf("expected the configuration to be untouched, got %#v", o)
+	}
+}
+
+func TestConfig_ReadIntoTagged(t *testing.T) {
+	type source struct {
+		Address string `config:"host"`
+		Port    int
+	}
+	type database struct {
+		Host     string `config:"host"`
+		Port     int64
+		Password string `config:"-"`
+	}
+	d := map[string]interface{}{"database": source{Address: "localhost", Port: 5432}}
+	db := database{Password: "kept"}
+	if err := ReadInto(New(&d), "database", &db); err != nil {
+		t.Fatal(err)
+	} else if expected := (database{Host: "localhost", Port: 5432, Password: "kept"}); !reflect.DeepEqual(db, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, db)
+	}
+}
+
+func TestConfig_ReadIntoIncompatible(t *testing.T) {
+	type database struct {
+		Port int
+	}
+	d := map[string]interface{}{"database": map[string]interface{}{"port": "default"}}
+	err := ReadInto(New(&d), "database", &database{})
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "database.port" {
+		t.Fatalf("expected %#v, got %#v", "database.port", e.Key())
+	}
+}
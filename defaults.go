@@ -0,0 +1,123 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// UnmarshalWithDefaults behaves like ReadInto, whereafter the struct fields still holding their zero value are set
+// from their `config:"name,default=value"` tag.
+//
+// As defaults only apply to zero-valued fields, a value explicitly configured as zero (e.g. `0` or `""`) is
+// indistinguishable from an absent value and is overridden by the field's default. An absent key is not an error, in
+// which case all defaults are applied.
+func UnmarshalWithDefaults(r Reader, key string, out interface{}) error {
+	if err := ReadInto(r, key, out); err != nil {
+		if !errors.Is(err, ErrKeyNotFound) {
+			return err
+		}
+	}
+	if kerr := applyDefaults(reflect.ValueOf(out).Elem()); kerr != nil {
+		kerr.From(key)
+		return kerr
+	}
+	return nil
+}
+
+// applyDefaults recursively sets the zero-valued fields of structs from their tagged default.
+func applyDefaults(element reflect.Value) KeyError {
+	switch element.Kind() {
+	case reflect.Ptr:
+		if !element.IsNil() {
+			return applyDefaults(element.Elem())
+		}
+	case reflect.Struct:
+		t := element.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, ok := fieldKey(f)
+			if f.PkgPath != "" || !ok {
+				continue
+			}
+			e := element.Field(i)
+			_, opts := parseTag(f)
+			if d, ok := opts.Value("default"); ok && e.IsZero() {
+				if err := parseString(e, d); err != nil {
+					err.From(name)
+					return err
+				}
+			}
+			if err := applyDefaults(e); err != nil {
+				err.From(name)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// parseString sets the settable element from a string representation, parsed per kind.
+func parseString(element reflect.Value, s string) KeyError {
+	t := element.Type()
+	if v, ok := unmarshalText(s, t); v.IsValid() {
+		if !ok {
+			return &ErrInvalidValue{Err: fmt.Errorf("invalid default %#v", s), ConfigurationError: &ConfigurationError{}}
+		}
+		element.Set(v)
+		return nil
+	}
+	var err error
+	switch t.Kind() {
+	case reflect.Ptr:
+		n := reflect.New(t.Elem())
+		if kerr := parseString(n.Elem(), s); kerr != nil {
+			return kerr
+		}
+		element.Set(n)
+		return nil
+	case reflect.String:
+		element.SetString(s)
+		return nil
+	case reflect.Bool:
+		var b bool
+		if b, err = strconv.ParseBool(s); err == nil {
+			element.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var i int64
+		if t == durationType {
+			var d time.Duration
+			d, err = time.ParseDuration(s)
+			i = int64(d)
+		} else {
+			i, err = strconv.ParseInt(s, 10, t.Bits())
+		}
+		if err == nil {
+			element.SetInt(i)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var u uint64
+		if u, err = strconv.ParseUint(s, 10, t.Bits()); err == nil {
+			element.SetUint(u)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		if f, err = strconv.ParseFloat(s, t.Bits()); err == nil {
+			element.SetFloat(f)
+			return nil
+		}
+	default:
+		return &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+	}
+	return &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+type settings struct {
+	Host    string        `config:"host,default=localhost"`
+	Port    int           `config:"port,default=8080"`
+	Debug   bool          `config:"debug,default=true"`
+	Timeout time.Duration `config:"timeout,default=5s"`
+	Limits  struct {
+		Ratio float64 `config:"ratio,default=0.5"`
+	} `config:"limits"`
+}
+
+func TestUnmarshalWithDefaults(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"host": "example.com", "port": 0}}
+	c := New(&d)
+	var s settings
+	if err := UnmarshalWithDefaults(c, "server", &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Host != "example.com" {
+		t.Fatalf("expected %#v, got %#v", "example.com", s.Host)
+	}
+	// Explicit zero values are indistinguishable from absent ones
+	if s.Port != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, s.Port)
+	}
+	if !s.Debug || s.Timeout != 5*time.Second || s.Limits.Ratio != 0.5 {
+		t.Fatalf("expected defaults, got %#v", s)
+	}
+}
+
+func TestUnmarshalWithDefaults_Absent(t *testing.T) {
+	c := New(&map[string]interface{}{})
+	var s settings
+	if err := UnmarshalWithDefaults(c, "server", &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Host != "localhost" || s.Port != 8080 {
+		t.Fatalf("expected defaults, got %#v", s)
+	}
+}
+
+func TestUnmarshalWithDefaults_Invalid(t *testing.T) {
+	var s struct {
+		Port int `config:"port,default=http"`
+	}
+	err := UnmarshalWithDefaults(New(&map[string]interface{}{}), "server", &s)
+	if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "server.port" {
+		t.Fatalf("expected %#v, got %#v", "server.port", e.Key())
+	}
+}
+
+func TestUnmarshalWithDefaults_LeadingZeros(t *testing.T) {
+	var s struct {
+		Port int  `config:"port,default=0080"`
+		Mode uint `config:"mode,default=010"`
+	}
+	if err := UnmarshalWithDefaults(New(&map[string]interface{}{}), "server", &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Port != 80 || s.Mode != 10 {
+		t.Fatalf("expected defaults, got %#v", s)
+	}
+}
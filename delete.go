@@ -0,0 +1,177 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// Deleter abstracts a configuration whose keys can be removed.
+type Deleter interface {
+	// Delete removes a key. Map entries and slice elements are removed while struct fields are reset to their zero value.
+	Delete(key string) error
+}
+
+// Resetter abstracts a configuration whose keys can be restored to their zero value.
+type Resetter interface {
+	// Reset restores a key to the zero value of its type. Map entries are removed rather than reset.
+	Reset(key string) error
+}
+
+// Delete removes a key. Map entries and slice elements are removed while struct fields are reset to their zero value.
+// Deleting a non-existent key results in an ErrNoSuchKey.
+func (c *config) Delete(key string) error {
+	return c.alter(key, c.remove)
+}
+
+// Reset restores a key to the zero value of its type. Map entries are removed rather than reset, letting layered
+// Readers provide their value again. Resetting a non-existent key results in an ErrNoSuchKey.
+func (c *config) Reset(key string) error {
+	return c.alter(key, c.reset)
+}
+
+// alter replaces the parent of a key by the element fn rebuilds from it and the key's last level.
+func (c *config) alter(key string, fn func(element reflect.Value, name string) (reflect.Value, KeyError)) error {
+	ctx := context.Background()
+	d := reflect.ValueOf(c.Data)
+	k, err := c.split(key)
+	if err != nil {
+		return err
+	}
+	parent, err := c.read(ctx, k[:len(k)-1], d)
+	if err != nil {
+		return err
+	}
+	p, err := fn(parent, k[len(k)-1])
+	if err != nil {
+		if len(k) > 1 {
+			c.from(err, c.join(k[:len(k)-1]))
+		}
+		return err
+	}
+	if len(k) == 1 {
+		c.Data = p.Interface()
+		return nil
+	}
+	// Write the rebuilt parent back
+	v, err := c.write(ctx, k[:len(k)-1], d, p.Interface())
+	if err != nil {
+		return err
+	}
+	c.Data = v.Interface()
+	return nil
+}
+
+// remove removes the named child of an element, returning the modified element.
+func (c *config) remove(element reflect.Value, name string) (reflect.Value, KeyError) {
+	switch k := element.Kind(); k {
+	case reflect.Interface, reflect.Ptr:
+		// Nil interfaces and pointers have no children
+		if element.IsNil() {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		e, err := c.remove(element.Elem(), name)
+		if err != nil {
+			return element, err
+		}
+		if k == reflect.Ptr {
+			element.Elem().Set(e)
+			return element, nil
+		}
+		return e, nil
+	case reflect.Struct:
+		// Find the matching exported field
+		i, ok := structField(element.Type(), name, c.Options.caseSensitive)
+		if !ok {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		n := reflect.New(element.Type()).Elem()
+		n.Set(element)
+		n.Field(i).Set(reflect.Zero(n.Field(i).Type()))
+		return n, nil
+	case reflect.Map:
+		// Find a matching key
+		k, found, err := mapKey(element, name, c.Options.caseSensitive)
+		if err != nil {
+			return element, err
+		} else if !found {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		element.SetMapIndex(k, reflect.Value{})
+		return element, nil
+	case reflect.Slice:
+		// Find the indexed element
+		i, perr := strconv.Atoi(name)
+		if perr != nil {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		} else if i < 0 || i >= element.Len() {
+			return element, &ErrIndexOutOfRange{Index: i, Len: element.Len(), ConfigurationError: &ConfigurationError{name}}
+		}
+		n := reflect.MakeSlice(element.Type(), 0, element.Len()-1)
+		n = reflect.AppendSlice(n, element.Slice(0, i))
+		n = reflect.AppendSlice(n, element.Slice(i+1, element.Len()))
+		return n, nil
+	default:
+		return element, &ErrUnhandledKind{Kind: k.String(), ConfigurationError: &ConfigurationError{name}}
+	}
+}
+
+// reset restores the named child of an element to its zero value, returning the modified element.
+// Map entries are removed rather than reset.
+func (c *config) reset(element reflect.Value, name string) (reflect.Value, KeyError) {
+	switch k := element.Kind(); k {
+	case reflect.Interface, reflect.Ptr:
+		// Nil interfaces and pointers have no children
+		if element.IsNil() {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		}
+		e, err := c.reset(element.Elem(), name)
+		if err != nil {
+			return element, err
+		}
+		if k == reflect.Ptr {
+			element.Elem().Set(e)
+			return element, nil
+		}
+		return e, nil
+	case reflect.Slice, reflect.Array:
+		// Find the indexed element
+		i, perr := strconv.Atoi(name)
+		if perr != nil {
+			return element, &ErrNoSuchKey{&ConfigurationError{name}}
+		} else if i < 0 || i >= element.Len() {
+			return element, &ErrIndexOutOfRange{Index: i, Len: element.Len(), ConfigurationError: &ConfigurationError{name}}
+		}
+		n := element
+		if k == reflect.Array || !n.Index(i).CanSet() {
+			n = reflect.New(element.Type()).Elem()
+			n.Set(element)
+		}
+		n.Index(i).Set(reflect.Zero(n.Index(i).Type()))
+		return n, nil
+	default:
+		return c.remove(element, name)
+	}
+}
+
+// Delete is a prefixed wrapper around the Deleter.
+func (s *sub) Delete(key string) error {
+	d, ok := s.RW.(Deleter)
+	if !ok {
+		return &ErrUnsupported{Operation: "deletion", ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	return d.Delete(s.resolve(key))
+}
+
+// Reset is a prefixed wrapper around the Resetter.
+func (s *sub) Reset(key string) error {
+	r, ok := s.RW.(Resetter)
+	if !ok {
+		return &ErrUnsupported{Operation: "resetting", ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	return r.Reset(s.resolve(key))
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Delete(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name    string
+		Servers map[string]server
+		Tags    []string
+	}
+	d := &data{
+		Name:    "demo",
+		Servers: map[string]server{"default": {Host: "localhost", Port: 80}, "backup": {Host: "remote"}},
+		Tags:    []string{"a", "b", "c"},
+	}
+	c := New(d).(Deleter)
+	for _, key := range []string{"name", "servers.backup", "servers.default.port", "tags.1"} {
+		if err := c.Delete(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := &data{Servers: map[string]server{"default": {Host: "localhost"}}, Tags: []string{"a", "c"}}
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+	err := c.Delete("servers.missing.port")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "servers.missing" {
+		t.Fatalf("expected %#v, got %#v", "servers.missing", e.Key())
+	}
+	err = c.Delete("servers.backup")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "servers.backup" {
+		t.Fatalf("expected %#v, got %#v", "servers.backup", e.Key())
+	}
+}
+
+func TestSub_Delete(t *testing.T) {
+	d := map[string]interface{}{"profiles": map[string]interface{}{"default": map[string]interface{}{"name": "demo"}}}
+	c := Sub(New(&d), "profiles.default").(Deleter)
+	if err := c.Delete("name"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{}; !reflect.DeepEqual(d["profiles"].(map[string]interface{})["default"], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d["profiles"])
+	}
+}
+
+func TestConfig_Reset(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Server    server
+		Overrides map[string]interface{}
+		Ports     [2]int
+	}
+	d := &data{Server: server{Host: "localhost", Port: 80}, Overrides: map[string]interface{}{"port": 8080}, Ports: [2]int{80, 443}}
+	c := New(d).(Resetter)
+	for _, key := range []string{"server.port", "overrides.port", "ports.1"} {
+		if err := c.Reset(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := &data{Server: server{Host: "localhost"}, Overrides: map[string]interface{}{}, Ports: [2]int{80, 0}}
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+	// Reset map entries fall back to the layered defaults
+	l := Layer(c.(Reader), WithDefaults(Layer(), map[string]interface{}{"overrides.port": 80}))
+	if v, err := l.Read("overrides.port"); err != nil {
+		t.Fatal(err)
+	} else if v != 80 {
+		t.Fatalf("expected %#v, got %#v", 80, v)
+	}
+	err := c.Reset("server.missing")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "server.missing" {
+		t.Fatalf("expected %#v, got %#v", "server.missing", e.Key())
+	}
+}
+
+func TestSub_Reset(t *testing.T) {
+	d := map[string]interface{}{"profiles": map[string]interface{}{"default": map[string]interface{}{"port": 80}}}
+	s := Sub(New(&d), "profiles.default").(Resetter)
+	if err := s.Reset("port"); err != nil {
+		t.Fatal(err)
+	} else if expected := map[string]interface{}{}; !reflect.DeepEqual(d["profiles"].(map[string]interface{})["default"], expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d["profiles"])
+	}
+}
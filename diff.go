@@ -0,0 +1,86 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+)
+
+// KeyDiff describes a key whose value differs between two Readers. A key missing from a Reader has a nil value and
+// is reported as absent by InA or InB.
+type KeyDiff struct {
+	Key string
+	A   interface{}
+	B   interface{}
+	InA bool
+	InB bool
+}
+
+// Diff compares the values two Readers provide for a set of keys, returning the differing keys sorted by key.
+// Without keys, all leaves of both Readers are compared, in which case the Readers must be enumerable as are
+// configurations created by New and Sub. Keys are matched like the Readers do and values compared deeply.
+func Diff(a, b Reader, keys []string) ([]KeyDiff, error) {
+	if keys == nil {
+		var err error
+		if keys, err = union(a, b); err != nil {
+			return nil, err
+		}
+	}
+	var diffs []KeyDiff
+	for _, key := range keys {
+		d := KeyDiff{Key: key}
+		var err error
+		if d.A, d.InA, err = lookup(a, key); err != nil {
+			return nil, err
+		}
+		if d.B, d.InB, err = lookup(b, key); err != nil {
+			return nil, err
+		}
+		if d.InA != d.InB || !reflect.DeepEqual(d.A, d.B) {
+			diffs = append(diffs, d)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs, nil
+}
+
+// union returns the deduplicated keys of all leaves of the Readers, matched case-insensitively unless the Reader is
+// case-sensitive.
+func union(readers ...Reader) ([]string, error) {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, r := range readers {
+		e, ok := r.(Enumerator)
+		if !ok {
+			return nil, &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+		}
+		k, err := e.Keys("")
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range k {
+			if l := foldKey(r, key); !seen[l] {
+				seen[l] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// lookup reads a key, reporting whether it is present rather than failing when it isn't.
+func lookup(r Reader, key string) (interface{}, bool, error) {
+	v, err := r.Read(key)
+	if errors.Is(err, ErrKeyNotFound) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+	return v, true, nil
+}
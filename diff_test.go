@@ -0,0 +1,42 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+		Tags []string
+	}
+	before := map[string]interface{}{"server": &server{Host: "localhost", Port: 80, Tags: []string{"a"}}, "debug": true}
+	after := map[string]interface{}{"server": &server{Host: "localhost", Port: 8080, Tags: []string{"a"}}, "name": "api"}
+	diffs, err := Diff(New(&before), New(&after), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []KeyDiff{
+		{Key: "debug", A: true, InA: true},
+		{Key: "name", B: "api", InB: true},
+		{Key: "server.port", A: 80, B: 8080, InA: true, InB: true},
+	}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, diffs)
+	}
+	if diffs, err := Diff(New(&before), New(&after), []string{"server.host", "server.tags"}); err != nil {
+		t.Fatal(err)
+	} else if len(diffs) != 0 {
+		t.Fatalf("unexpected %#v differences", diffs)
+	}
+	if _, err := Diff(Env("app"), New(&after), nil); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrUnsupported); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// durationType is the type of time.Duration, whose string representations are parsed using time.ParseDuration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// toDuration converts a value into a duration.
+// Numbers are considered nanoseconds while strings such as `30s` are parsed using time.ParseDuration, malformed
+// strings resulting in an ErrInvalidValue.
+func toDuration(v interface{}) (time.Duration, KeyError) {
+	if d, ok := v.(time.Duration); ok {
+		return d, nil
+	}
+	if n, err := toInt64(v); err == nil {
+		return time.Duration(n), nil
+	}
+	if s, ok := v.(string); ok {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return 0, &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+		}
+		return d, nil
+	}
+	return 0, &ErrIncompatibleType{Type: durationType.String(), ConfigurationError: &ConfigurationError{}}
+}
+
+// ReadDuration behaves like the Reader's Read with additional duration conversion taking place.
+// Numbers are considered nanoseconds while strings such as `1h30m` are parsed using time.ParseDuration.
+func ReadDuration(r Reader, key string) (time.Duration, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	d, kerr := toDuration(v)
+	if kerr != nil {
+		kerr.From(key)
+		return 0, kerr
+	}
+	return d, nil
+}
+
+// ReadDurationMap behaves like the Reader's Read with the values of a map converted into durations.
+// Entries failing to convert are aggregated as Errors while any kind other than a map results in an ErrKindMismatch.
+func ReadDurationMap(r Reader, key string) (map[string]time.Duration, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	val := indirect(reflect.ValueOf(v))
+	if k := val.Kind(); k != reflect.Map {
+		return nil, &ErrKindMismatch{Kind: k.String(), Expected: reflect.Map.String(), ConfigurationError: &ConfigurationError{key}}
+	}
+	m := make(map[string]time.Duration, val.Len())
+	var errs Errors
+	i := val.MapRange()
+	for i.Next() {
+		name := fmt.Sprint(i.Key().Interface())
+		d, kerr := toDuration(i.Value().Interface())
+		if kerr != nil {
+			prependKey(kerr, name, separatorOf(r))
+			prependKey(kerr, key, separatorOf(r))
+			errs = append(errs, kerr)
+			continue
+		}
+		m[name] = d
+	}
+	if len(errs) > 0 {
+		sort.Slice(errs, func(i, j int) bool {
+			return errs[i].Key() < errs[j].Key()
+		})
+		return nil, errs
+	}
+	return m, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReadDurationMap(t *testing.T) {
+	d := map[string]interface{}{"intervals": map[string]interface{}{
+		"poll":  30 * time.Second,
+		"retry": "5s",
+		"tick":  1000000,
+	}}
+	expected := map[string]time.Duration{"poll": 30 * time.Second, "retry": 5 * time.Second, "tick": time.Millisecond}
+	if v, err := ReadDurationMap(New(&d), "intervals"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(v, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, v)
+	}
+}
+
+func TestReadDurationMap_Invalid(t *testing.T) {
+	d := map[string]interface{}{
+		"intervals": map[string]interface{}{"poll": "soon", "retry": true, "tick": "1ms"},
+		"timeout":   "5s",
+	}
+	c := New(&d)
+	_, err := ReadDurationMap(c, "intervals")
+	if e, ok := err.(Errors); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if len(e) != 2 {
+		t.Fatalf("expected %#v, got %#v", 2, len(e))
+	} else if e[0].Key() != "intervals.poll" || e[1].Key() != "intervals.retry" {
+		t.Fatalf("expected %#v, got %#v", []string{"intervals.poll", "intervals.retry"}, []string{e[0].Key(), e[1].Key()})
+	}
+	_, err = ReadDurationMap(c, "timeout")
+	if e, ok := err.(*ErrKindMismatch); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestReadDuration(t *testing.T) {
+	tests := map[interface{}]time.Duration{
+		5 * time.Second: 5 * time.Second,
+		"30s":           30 * time.Second,
+		" 1h30m ":       90 * time.Minute,
+		1000:            time.Microsecond,
+		"1000":          time.Microsecond,
+	}
+	for v, expected := range tests {
+		c := New(&map[string]interface{}{"timeout": v})
+		if d, err := ReadDuration(c, "timeout"); err != nil {
+			t.Fatal(err)
+		} else if d != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, v, d)
+		}
+	}
+	c := New(&map[string]interface{}{"timeout": "30 parsecs"})
+	_, err := ReadDuration(c, "timeout")
+	if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "timeout" {
+		t.Fatalf("expected %#v, got %#v", "timeout", e.Key())
+	}
+	c = New(&map[string]interface{}{"timeout": true})
+	_, err = ReadDuration(c, "timeout")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestConfig_ReadStringDuration(t *testing.T) {
+	type data struct {
+		Timeout time.Duration
+	}
+	c := New(&data{Timeout: 30 * time.Second})
+	if v, err := c.ReadString("timeout"); err != nil {
+		t.Fatal(err)
+	} else if v != "30s" {
+		t.Fatalf("expected %#v, got %#v", "30s", v)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// envReplacer replaces the key characters which can't be part of an environment variable name.
+var envReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// envName translates a key into its environment variable name, such that `database.host` with the `app` prefix
+// becomes `APP_DATABASE_HOST`.
+func envName(prefix, key string) string {
+	name := strings.ToUpper(envReplacer.Replace(key))
+	if prefix == "" {
+		return name
+	}
+	return strings.ToUpper(envReplacer.Replace(prefix)) + "_" + name
+}
+
+// ToEnviron exports the leaves of a Reader as `PREFIX_DATABASE_HOST=value` environment variable assignments, suitable
+// for exec.Cmd's Env. The Reader must be enumerable, as are configurations created by New and Sub.
+//
+// Secret-tagged keys are included unless the Redacted option is provided, in which case they are omitted.
+func ToEnviron(r Reader, prefix string, opts ...Option) ([]string, error) {
+	w, ok := r.(walker)
+	if !ok {
+		return nil, &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+	}
+	o := newOptions(opts)
+	var env []string
+	err := w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		if o.redacted && field != nil && secret(*field) {
+			return nil
+		}
+		s, err := r.ReadString(key)
+		if err != nil {
+			return err
+		}
+		env = append(env, envName(prefix, key)+"="+s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(env)
+	return env, nil
+}
+
+// Env creates a Reader backed by the environment variables, such that `database.host` with the `app` prefix reads the
+// `APP_DATABASE_HOST` variable. Values are always strings while unset variables result in an ErrNoSuchKey.
+//
+// Env is meant to be layered over other configurations, for example using Layer.
+func Env(prefix string) Reader {
+	e := &env{Prefix: prefix}
+	e.accessor = accessor{e.Read}
+	return e
+}
+
+// env is a Reader backed by the environment variables.
+type env struct {
+	accessor
+	Prefix string
+}
+
+// Read gets the value of the environment variable matching a key.
+func (e *env) Read(key string) (interface{}, error) {
+	v, ok := os.LookupEnv(envName(e.Prefix, key))
+	if !ok {
+		return nil, &ErrNoSuchKey{&ConfigurationError{key}}
+	}
+	return v, nil
+}
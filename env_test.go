@@ -0,0 +1,70 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestToEnviron(t *testing.T) {
+	type database struct {
+		Host     string
+		Port     int
+		Password string `config:",secret"`
+	}
+	type data struct {
+		Database database
+		Labels   map[string]string
+	}
+	c := New(&data{Database: database{Host: "localhost", Port: 5432, Password: "hunter2"}, Labels: map[string]string{"tier": "web"}})
+	expected := []string{"APP_DATABASE_HOST=localhost", "APP_DATABASE_PASSWORD=hunter2", "APP_DATABASE_PORT=5432", "APP_LABELS_TIER=web"}
+	if env, err := ToEnviron(c, "app"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(env, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, env)
+	}
+	expected = []string{"DATABASE_HOST=localhost", "DATABASE_PORT=5432", "LABELS_TIER=web"}
+	if env, err := ToEnviron(c, "", Redacted()); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(env, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, env)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	t.Setenv("APP_DATABASE_HOST", "localhost")
+	t.Setenv("APP_DATABASE_PORT", "5432")
+	c := Env("app")
+	if v, err := c.ReadString("database.host"); err != nil {
+		t.Fatal(err)
+	} else if v != "localhost" {
+		t.Fatalf("expected %#v, got %#v", "localhost", v)
+	}
+	if v, err := c.Read("database.port"); err != nil {
+		t.Fatal(err)
+	} else if v != "5432" {
+		t.Fatalf("expected %#v, got %#v", "5432", v)
+	}
+	_, err := c.ReadString("database.name")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "database.name" {
+		t.Fatalf("expected %#v, got %#v", "database.name", e.Key())
+	}
+	// Layer the environment over defaults
+	defaults := map[string]interface{}{"database": map[string]interface{}{"port": 3306, "name": "demo"}}
+	l := Layer(c, New(&defaults))
+	if v, err := ReadInt(l, "database.port"); err != nil {
+		t.Fatal(err)
+	} else if v != 5432 {
+		t.Fatalf("expected %#v, got %#v", 5432, v)
+	}
+	if v, err := l.ReadString("database.name"); err != nil {
+		t.Fatal(err)
+	} else if v != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", v)
+	}
+}
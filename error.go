@@ -5,7 +5,20 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+)
+
+var (
+	// ErrKeyNotFound is matched by ErrNoSuchKey and ErrIndexOutOfRange errors when using errors.Is.
+	ErrKeyNotFound = errors.New("configuration key not found")
+	// ErrIncompatible is matched by ErrIncompatibleType errors when using errors.Is.
+	ErrIncompatible = errors.New("configuration key has an incompatible type")
+	// ErrUnhandled is matched by ErrUnhandledKind errors when using errors.Is.
+	ErrUnhandled = errors.New("configuration key has an unhandled kind")
+	// ErrReadOnly is returned by the writes of a ReadOnly configuration.
+	ErrReadOnly = errors.New("configuration is read-only")
 )
 
 // KeyError is an error whose key can be recursively set.
@@ -30,7 +43,16 @@ func (e *ConfigurationError) Error() string {
 }
 
 func (e *ConfigurationError) From(key string) {
-	e.Keys = key + "." + e.Keys
+	e.prepend(key, ".")
+}
+
+// prepend prepends the key with the provided key using a custom separator.
+func (e *ConfigurationError) prepend(key, sep string) {
+	if e.Keys == "" {
+		e.Keys = key
+		return
+	}
+	e.Keys = key + sep + e.Keys
 }
 
 type ErrNoSuchKey struct {
@@ -41,6 +63,17 @@ func (e *ErrNoSuchKey) Error() string {
 	return fmt.Sprintf("no such %#v configuration key", e.Key())
 }
 
+func (e *ErrNoSuchKey) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+func (e *ErrNoSuchKey) Unwrap() error {
+	if e.ConfigurationError == nil {
+		return nil
+	}
+	return e.ConfigurationError
+}
+
 type ErrUnhandledKind struct {
 	*ConfigurationError
 	Kind string
@@ -50,6 +83,17 @@ func (e *ErrUnhandledKind) Error() string {
 	return fmt.Sprintf("configuration key %#v has an undhandled kind %#v", e.Key(), e.Kind)
 }
 
+func (e *ErrUnhandledKind) Is(target error) bool {
+	return target == ErrUnhandled
+}
+
+func (e *ErrUnhandledKind) Unwrap() error {
+	if e.ConfigurationError == nil {
+		return nil
+	}
+	return e.ConfigurationError
+}
+
 type ErrIncompatibleType struct {
 	*ConfigurationError
 	Type string
@@ -58,3 +102,153 @@ type ErrIncompatibleType struct {
 func (e *ErrIncompatibleType) Error() string {
 	return fmt.Sprintf("configuration key %#v has an incompatible kind %#v", e.Key(), e.Type)
 }
+
+func (e *ErrIncompatibleType) Is(target error) bool {
+	return target == ErrIncompatible
+}
+
+func (e *ErrIncompatibleType) Unwrap() error {
+	if e.ConfigurationError == nil {
+		return nil
+	}
+	return e.ConfigurationError
+}
+
+type ErrUnsupported struct {
+	*ConfigurationError
+	Operation string
+}
+
+func (e *ErrUnsupported) Error() string {
+	return fmt.Sprintf("configuration key %#v does not support %#v", e.Key(), e.Operation)
+}
+
+type ErrReferenceCycle struct {
+	*ConfigurationError
+	Reference string
+}
+
+func (e *ErrReferenceCycle) Error() string {
+	return fmt.Sprintf("configuration key %#v has a cyclic reference to %#v", e.Key(), e.Reference)
+}
+
+// Errors aggregates the errors of multiple keys.
+type Errors []KeyError
+
+func (e Errors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+type ErrKeyNotWritable struct {
+	*ConfigurationError
+}
+
+func (e *ErrKeyNotWritable) Error() string {
+	return fmt.Sprintf("configuration key %#v is not writable", e.Key())
+}
+
+type ErrAbsoluteKey struct {
+	*ConfigurationError
+	Prefix string
+}
+
+func (e *ErrAbsoluteKey) Error() string {
+	return fmt.Sprintf("configuration key %#v must be relative to the %#v prefix", e.Key(), e.Prefix)
+}
+
+type ErrKindMismatch struct {
+	*ConfigurationError
+	Kind     string
+	Expected string
+}
+
+func (e *ErrKindMismatch) Error() string {
+	return fmt.Sprintf("configuration key %#v has a %#v kind rather than a %#v kind", e.Key(), e.Kind, e.Expected)
+}
+
+type ErrInvalidValue struct {
+	*ConfigurationError
+	Err error
+}
+
+func (e *ErrInvalidValue) Error() string {
+	return fmt.Sprintf("configuration key %#v has an invalid value: %v", e.Key(), e.Err)
+}
+
+func (e *ErrInvalidValue) Unwrap() error {
+	return e.Err
+}
+
+type ErrCanceled struct {
+	*ConfigurationError
+	Err error
+}
+
+func (e *ErrCanceled) Error() string {
+	return fmt.Sprintf("configuration key %#v traversal was canceled: %v", e.Key(), e.Err)
+}
+
+func (e *ErrCanceled) Unwrap() error {
+	return e.Err
+}
+
+type ErrLimitExceeded struct {
+	*ConfigurationError
+	Limit string
+	Max   int
+}
+
+func (e *ErrLimitExceeded) Error() string {
+	return fmt.Sprintf("configuration key %#v exceeds the %s limit of %d", e.Key(), e.Limit, e.Max)
+}
+
+type ErrIndexOutOfRange struct {
+	*ConfigurationError
+	Index int
+	Len   int
+}
+
+func (e *ErrIndexOutOfRange) Error() string {
+	return fmt.Sprintf("configuration key %#v index %d out of range (len %d)", e.Key(), e.Index, e.Len)
+}
+
+func (e *ErrIndexOutOfRange) Is(target error) bool {
+	return target == ErrKeyNotFound
+}
+
+func (e *ErrIndexOutOfRange) Unwrap() error {
+	if e.ConfigurationError == nil {
+		return nil
+	}
+	return e.ConfigurationError
+}
+
+type ErrVersionConflict struct {
+	*ConfigurationError
+	Expected uint64
+	Actual   uint64
+}
+
+func (e *ErrVersionConflict) Error() string {
+	return fmt.Sprintf("configuration key %#v was not written as version %d was expected rather than version %d", e.Key(), e.Expected, e.Actual)
+}
+
+type ErrUnknownKey struct {
+	*ConfigurationError
+}
+
+func (e *ErrUnknownKey) Error() string {
+	return fmt.Sprintf("configuration key %#v is unknown to the schema", e.Key())
+}
+
+type ErrEmptyValue struct {
+	*ConfigurationError
+}
+
+func (e *ErrEmptyValue) Error() string {
+	return fmt.Sprintf("configuration key %#v has an empty value", e.Key())
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrors_Is(t *testing.T) {
+	d := map[string]interface{}{"name": "demo", "port": "http"}
+	c := New(&d)
+	_, err := c.Read("missing")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected %#v, got %#v", ErrKeyNotFound, err)
+	} else if errors.Is(err, ErrIncompatible) {
+		t.Fatalf("unexpected %#v match", ErrIncompatible)
+	}
+	_, err = ReadInt(c, "port")
+	if !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("expected %#v, got %#v", ErrIncompatible, err)
+	}
+	_, err = c.Read("name.first")
+	if !errors.Is(err, ErrUnhandled) {
+		t.Fatalf("expected %#v, got %#v", ErrUnhandled, err)
+	}
+	// Wrapping errors still match
+	_, err = c.Read("missing")
+	if err := fmt.Errorf("loading: %w", err); !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected %#v, got %#v", ErrKeyNotFound, err)
+	}
+}
+
+func TestErrors_As(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"name": "demo", "port": "http"}}
+	c := New(&d)
+	_, err1 := c.Read("server.missing")
+	_, err2 := ReadInt(c, "server.port")
+	_, err3 := c.Read("server.name.first")
+	for key, err := range map[string]error{"server.missing": err1, "server.port": err2, "server.name.first": err3} {
+		var cerr *ConfigurationError
+		if !errors.As(err, &cerr) {
+			t.Fatalf("expected %T error, got %T error", cerr, err)
+		} else if cerr.Key() != key {
+			t.Fatalf("expected %#v, got %#v", key, cerr.Key())
+		}
+	}
+}
+
+func TestErrIndexOutOfRange(t *testing.T) {
+	d := map[string]interface{}{"servers": []string{"a", "b"}}
+	c := New(&d)
+	_, err := c.Read("servers.5")
+	if !errors.Is(err, ErrKeyNotFound) {
+		t.Fatalf("expected %#v, got %#v", ErrKeyNotFound, err)
+	}
+	var cerr *ConfigurationError
+	if !errors.As(err, &cerr) {
+		t.Fatalf("expected %T error, got %T error", cerr, err)
+	}
+	if expected := `configuration key "servers.5" index 5 out of range (len 2)`; err.Error() != expected {
+		t.Fatalf("expected %#v, got %#v", expected, err.Error())
+	}
+	if err := c.(Deleter).Delete("servers.2"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIndexOutOfRange); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
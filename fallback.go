@@ -0,0 +1,42 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+)
+
+// WithDefaults abstracts a Reader falling back to default values for the keys it doesn't provide.
+//
+// Defaults are looked up by their full key, case-insensitively unless the Reader is case-sensitive, whenever the
+// Reader returns an ErrNoSuchKey, the error being propagated if no default exists. Typed reads such as ReadString
+// convert defaults like any other value.
+func WithDefaults(r Reader, defaults map[string]interface{}) Reader {
+	d := make(map[string]interface{}, len(defaults))
+	for k, v := range defaults {
+		d[foldKey(r, k)] = v
+	}
+	f := &fallback{R: r, Defaults: d}
+	f.accessor = accessor{f.Read}
+	return f
+}
+
+// fallback is a Reader falling back to default values.
+type fallback struct {
+	accessor
+	R        Reader
+	Defaults map[string]interface{}
+}
+
+// Read is a wrapper around the Reader falling back to the defaults.
+func (f *fallback) Read(key string) (interface{}, error) {
+	v, err := f.R.Read(key)
+	if errors.Is(err, ErrKeyNotFound) {
+		if d, ok := f.Defaults[foldKey(f.R, key)]; ok {
+			return d, nil
+		}
+	}
+	return v, err
+}
@@ -0,0 +1,32 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestWithDefaults(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"host": "example.com"}}
+	c := WithDefaults(New(&d), map[string]interface{}{"server.host": "localhost", "Server.Port": 8080, "debug": true})
+	tests := map[string]string{
+		"server.host": "example.com",
+		"server.port": "8080",
+		"debug":       "true",
+	}
+	for key, expected := range tests {
+		if v, err := c.ReadString(key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, key, v)
+		}
+	}
+	_, err := c.Read("server.name")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "server.name" {
+		t.Fatalf("expected %#v, got %#v", "server.name", e.Key())
+	}
+}
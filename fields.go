@@ -0,0 +1,35 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+)
+
+// Fields returns the keys of the exported fields of the struct at key in the Reader, respecting their tags.
+//
+// Fields allows for form generation over struct sub-configurations, a non-struct value resulting in an ErrKindMismatch.
+func Fields(r Reader, key string) ([]string, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	e := indirect(reflect.ValueOf(v))
+	if e.Kind() != reflect.Struct {
+		return nil, &ErrKindMismatch{Kind: e.Kind().String(), Expected: reflect.Struct.String(), ConfigurationError: &ConfigurationError{key}}
+	}
+	t := e.Type()
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if name, ok := fieldName(f, caseSensitiveOf(r)); ok {
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
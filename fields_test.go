@@ -0,0 +1,34 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	type server struct {
+		Host     string
+		APIKey   string `config:"api_key"`
+		Internal string `config:"-"`
+		hidden   string
+	}
+	type data struct {
+		Server *server
+	}
+	c := New(&data{Server: &server{}})
+	expected := []string{"host", "api_key"}
+	if fields, err := Fields(c, "server"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(fields, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, fields)
+	}
+	if _, err := Fields(c, "server.host"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrKindMismatch); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
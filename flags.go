@@ -0,0 +1,48 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"flag"
+	"strings"
+)
+
+// Flags creates a Reader backed by the command-line flags set, such that `log.level` reads either the `log.level` or
+// the `log-level` flag. Flag names are matched case-insensitively.
+//
+// Only the flags set while parsing are provided, unset flags resulting in an ErrNoSuchKey so that Flags can be
+// layered over other configurations, for example using Layer. Flag values implementing flag.Getter provide their
+// typed value while others provide their string representation.
+func Flags(fs *flag.FlagSet) Reader {
+	f := &flags{Set: fs}
+	f.accessor = accessor{f.Read}
+	return f
+}
+
+// flags is a Reader backed by the set command-line flags.
+type flags struct {
+	accessor
+	Set *flag.FlagSet
+}
+
+// Read gets the value of the set flag matching a key.
+func (f *flags) Read(key string) (interface{}, error) {
+	names := []string{key, strings.ReplaceAll(key, ".", "-")}
+	var found *flag.Flag
+	f.Set.Visit(func(fl *flag.Flag) {
+		for _, name := range names {
+			if found == nil && strings.EqualFold(fl.Name, name) {
+				found = fl
+			}
+		}
+	})
+	if found == nil {
+		return nil, &ErrNoSuchKey{&ConfigurationError{key}}
+	}
+	if g, ok := found.Value.(flag.Getter); ok {
+		return g.Get(), nil
+	}
+	return found.Value.String(), nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"flag"
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("log-level", "info", "")
+	fs.Int("server.port", 80, "")
+	fs.Bool("verbose", false, "")
+	if err := fs.Parse([]string{"-log-level=debug", "-server.port=8080"}); err != nil {
+		t.Fatal(err)
+	}
+	c := Flags(fs)
+	if v, err := c.ReadString("log.level"); err != nil {
+		t.Fatal(err)
+	} else if v != "debug" {
+		t.Fatalf("expected %#v, got %#v", "debug", v)
+	}
+	if v, err := c.Read("Server.Port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+	// Unset flags fall through to the layered defaults
+	d := map[string]interface{}{"verbose": true}
+	if v, err := ReadBool(Layer(c, New(&d)), "verbose"); err != nil {
+		t.Fatal(err)
+	} else if !v {
+		t.Fatalf("expected %#v, got %#v", true, v)
+	}
+	_, err := c.Read("verbose")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "verbose" {
+		t.Fatalf("expected %#v, got %#v", "verbose", e.Key())
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Get reads a key's value from the Reader and converts it into a T.
+// Scalars are converted as by the typed reads, such that strings are parsed and lossy conversions, such as fractional
+// floats into integers, result in an ErrIncompatibleType. Other types are decoded as by ReadInto.
+func Get[T any](r Reader, key string) (T, error) {
+	var t T
+	v, err := r.Read(key)
+	if err != nil {
+		return t, err
+	}
+	if c, ok := v.(T); ok {
+		return c, nil
+	}
+	dst := reflect.ValueOf(&t).Elem()
+	if n, ok, kerr := scalar(v, dst.Type()); kerr != nil {
+		kerr.From(key)
+		return t, kerr
+	} else if ok {
+		dst.Set(n)
+		return t, nil
+	}
+	if kerr := decode(dst, reflect.ValueOf(v)); kerr != nil {
+		kerr.From(key)
+		return t, kerr
+	}
+	return t, nil
+}
+
+// scalar converts a value into a scalar of type t, the boolean reporting whether t is a supported scalar.
+// Strings are unmarshaled into encoding.TextUnmarshaler implementations, while durations, booleans and numbers are
+// converted using their respective typed conversions. Numbers overflowing t result in an ErrInvalidValue.
+func scalar(v interface{}, t reflect.Type) (reflect.Value, bool, KeyError) {
+	if s, ok := v.(string); ok {
+		if n, ok := unmarshalText(s, t); ok {
+			return n, true, nil
+		} else if n.IsValid() {
+			return reflect.Value{}, true, &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{}}
+		}
+	}
+	n := reflect.New(t).Elem()
+	var err KeyError
+	switch k := t.Kind(); {
+	case t == durationType:
+		var d time.Duration
+		d, err = toDuration(v)
+		n.SetInt(int64(d))
+	case k == reflect.Bool:
+		var b bool
+		b, err = toBool(v)
+		n.SetBool(b)
+	case k == reflect.String:
+		var s string
+		s, err = toString(v)
+		n.SetString(s)
+	case k >= reflect.Int && k <= reflect.Int64:
+		var i int64
+		if i, err = toInt64(v); err == nil && n.OverflowInt(i) {
+			return reflect.Value{}, true, &ErrInvalidValue{Err: &strconv.NumError{Func: "ParseInt", Num: fmt.Sprint(v), Err: strconv.ErrRange}, ConfigurationError: &ConfigurationError{}}
+		}
+		n.SetInt(i)
+	case k >= reflect.Uint && k <= reflect.Uintptr:
+		var u uint64
+		if u, err = toUint64(v); err == nil && n.OverflowUint(u) {
+			return reflect.Value{}, true, &ErrInvalidValue{Err: &strconv.NumError{Func: "ParseUint", Num: fmt.Sprint(v), Err: strconv.ErrRange}, ConfigurationError: &ConfigurationError{}}
+		}
+		n.SetUint(u)
+	case k == reflect.Float32 || k == reflect.Float64:
+		var f float64
+		if f, err = toFloat64(v); err == nil && n.OverflowFloat(f) {
+			return reflect.Value{}, true, &ErrInvalidValue{Err: &strconv.NumError{Func: "ParseFloat", Num: fmt.Sprint(v), Err: strconv.ErrRange}, ConfigurationError: &ConfigurationError{}}
+		}
+		n.SetFloat(f)
+	default:
+		return reflect.Value{}, false, nil
+	}
+	if err != nil {
+		if ierr, ok := err.(*ErrIncompatibleType); ok {
+			ierr.Type = t.String()
+		}
+		return reflect.Value{}, true, err
+	}
+	return n, true, nil
+}
+
+// GetLayered reads a key's value from the first Reader providing it and converts it into a T.
+//
+// Readers returning an ErrNoSuchKey are skipped, allowing for environment-over-file-over-defaults lookups.
+// By default, a present value which can't be converted into a T results in an error. The SkipIncompatible option
+// continues with the next Reader instead, the last conversion error being returned if no Reader succeeds.
+func GetLayered[T any](key string, readers []Reader, opts ...Option) (T, error) {
+	var t T
+	o := newOptions(opts)
+	var err error = &ErrNoSuchKey{&ConfigurationError{key}}
+	for _, r := range readers {
+		v, rerr := Get[T](r, key)
+		var nerr *ErrNoSuchKey
+		var ierr *ErrIncompatibleType
+		switch {
+		case errors.As(rerr, &nerr):
+			if _, ok := err.(*ErrIncompatibleType); !ok {
+				err = rerr
+			}
+		case errors.As(rerr, &ierr) && o.skipIncompatible:
+			err = rerr
+		case rerr != nil:
+			return t, rerr
+		default:
+			return v, nil
+		}
+	}
+	return t, err
+}
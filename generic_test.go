@@ -0,0 +1,108 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	type data struct {
+		Port int
+		Name string
+	}
+	c := New(&data{Port: 8080, Name: "demo"})
+	if port, err := Get[int64](c, "port"); err != nil {
+		t.Fatal(err)
+	} else if port != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, port)
+	}
+	if port, err := Get[string](c, "port"); err != nil {
+		t.Fatal(err)
+	} else if port != "8080" {
+		t.Fatalf("expected %#v, got %#v", "8080", port)
+	}
+	for _, v := range []float64{0.75, 7.5} {
+		if _, err := Get[int](New(&map[string]interface{}{"ratio": v}), "ratio"); err == nil {
+			t.Fatal("expected error but got none")
+		} else if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		}
+	}
+	if _, err := Get[int8](New(&map[string]interface{}{"port": 8080}), "port"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	if _, err := Get[[]int](c, "name"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestGetLayered(t *testing.T) {
+	env := New(&map[string]interface{}{"port": 9090})
+	file := New(&map[string]interface{}{"port": 8080, "host": "localhost"})
+	defaults := New(&map[string]interface{}{"port": 80, "host": "0.0.0.0", "name": "demo"})
+	readers := []Reader{env, file, defaults}
+	tests := map[string]string{
+		"port": "9090",
+		"host": "localhost",
+		"name": "demo",
+	}
+	for key, expected := range tests {
+		if v, err := GetLayered[string](key, readers); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	if _, err := GetLayered[string]("missing", readers); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestGetLayeredEnv(t *testing.T) {
+	t.Setenv("APP_PORT", "9090")
+	t.Setenv("APP_TIMEOUT", "5s")
+	t.Setenv("APP_DEBUG", "true")
+	file := New(&map[string]interface{}{"port": 8080, "timeout": "1s", "debug": false})
+	readers := []Reader{Env("app"), file}
+	if port, err := GetLayered[int]("port", readers); err != nil {
+		t.Fatal(err)
+	} else if port != 9090 {
+		t.Fatalf("expected %#v, got %#v", 9090, port)
+	}
+	if timeout, err := GetLayered[time.Duration]("timeout", readers); err != nil {
+		t.Fatal(err)
+	} else if timeout != 5*time.Second {
+		t.Fatalf("expected %#v, got %#v", 5*time.Second, timeout)
+	}
+	if debug, err := GetLayered[bool]("debug", readers); err != nil {
+		t.Fatal(err)
+	} else if !debug {
+		t.Fatalf("expected %#v, got %#v", true, debug)
+	}
+}
+
+func TestGetLayeredSkipIncompatible(t *testing.T) {
+	env := New(&map[string]interface{}{"ports": "none"})
+	file := New(&map[string]interface{}{"ports": []int{80, 443}})
+	readers := []Reader{env, file}
+	if _, err := GetLayered[[]int]("ports", readers); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	if ports, err := GetLayered[[]int]("ports", readers, SkipIncompatible()); err != nil {
+		t.Fatal(err)
+	} else if len(ports) != 2 {
+		t.Fatalf("expected %#v ports, got %#v", 2, len(ports))
+	}
+}
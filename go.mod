@@ -1,3 +1,3 @@
 module github.com/0xThiebaut/go-config
 
-go 1.17
+go 1.18
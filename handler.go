@@ -0,0 +1,59 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"sync"
+)
+
+// handlers holds the registered typeHandler keyed by their reflect.Type.
+var handlers sync.Map
+
+// typeHandler holds the read and write handlers of a registered type.
+type typeHandler struct {
+	Read  func(interface{}) (interface{}, error)
+	Write func(interface{}) (interface{}, error)
+}
+
+// RegisterType routes the values of type t through handlers when read or written, enabling field-level transformations
+// such as transparent encryption. Either handler may be nil, and registering a type again replaces its handlers.
+//
+// On read, onRead is provided the stored value and its result is returned, conversions such as ReadString taking place
+// on that result. On write, onWrite is provided the written value, after any WithWriteTransform, and its result is
+// converted into t before being stored. Handler errors are returned as an ErrInvalidValue.
+//
+// Registered types apply to all configurations.
+func RegisterType(t reflect.Type, onRead, onWrite func(interface{}) (interface{}, error)) {
+	handlers.Store(t, typeHandler{Read: onRead, Write: onWrite})
+}
+
+// handle routes an element through the handler registered for the type t, if any.
+func handle(t reflect.Type, v interface{}, write bool) (interface{}, KeyError) {
+	h, ok := handlers.Load(t)
+	if !ok {
+		return v, nil
+	}
+	fn := h.(typeHandler).Read
+	if write {
+		fn = h.(typeHandler).Write
+	}
+	if fn == nil {
+		return v, nil
+	}
+	v, err := fn(v)
+	if err != nil {
+		return nil, &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+	}
+	return v, nil
+}
+
+// handledType returns the type whose handler applies to an element, interfaces resolving to the type they hold.
+func handledType(element reflect.Value) reflect.Type {
+	if element.Kind() == reflect.Interface && !element.IsNil() {
+		return element.Elem().Type()
+	}
+	return element.Type()
+}
@@ -0,0 +1,86 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rot13 is a reversibly obfuscated string.
+type rot13 string
+
+// rotate applies the reversible rot13 substitution.
+func rotate(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return 'a' + (r-'a'+13)%26
+		case r >= 'A' && r <= 'Z':
+			return 'A' + (r-'A'+13)%26
+		}
+		return r
+	}, s)
+}
+
+func init() {
+	RegisterType(reflect.TypeOf(rot13("")), func(v interface{}) (interface{}, error) {
+		return rotate(string(v.(rot13))), nil
+	}, func(v interface{}) (interface{}, error) {
+		s, ok := v.(string)
+		if !ok {
+			return nil, errors.New("expected a string")
+		}
+		return rot13(rotate(s)), nil
+	})
+}
+
+func TestRegisterType(t *testing.T) {
+	type data struct {
+		Secret rot13
+		Plain  string
+	}
+	d := &data{}
+	c := New(d)
+	if err := c.Write("secret", "Hello"); err != nil {
+		t.Fatal(err)
+	} else if d.Secret != "Uryyb" {
+		t.Fatalf("expected %#v, got %#v", rot13("Uryyb"), d.Secret)
+	}
+	if s, err := c.ReadString("secret"); err != nil {
+		t.Fatal(err)
+	} else if s != "Hello" {
+		t.Fatalf("expected %#v, got %#v", "Hello", s)
+	}
+	if err := c.Write("plain", "Hello"); err != nil {
+		t.Fatal(err)
+	} else if d.Plain != "Hello" {
+		t.Fatalf("expected %#v, got %#v", "Hello", d.Plain)
+	}
+	if err := c.Write("secret", 1); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "secret" {
+		t.Fatalf("expected %#v, got %#v", "secret", e.Key())
+	}
+}
+
+func TestRegisterType_Interface(t *testing.T) {
+	d := map[string]interface{}{"secret": rot13("Uryyb")}
+	c := New(&d)
+	if s, err := c.ReadString("secret"); err != nil {
+		t.Fatal(err)
+	} else if s != "Hello" {
+		t.Fatalf("expected %#v, got %#v", "Hello", s)
+	}
+	if err := c.Write("secret", "xyz"); err != nil {
+		t.Fatal(err)
+	} else if d["secret"] != rot13("klm") {
+		t.Fatalf("expected %#v, got %#v", rot13("klm"), d["secret"])
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Saver abstracts a configuration which can be serialized.
+type Saver interface {
+	// SaveJSON encodes the configuration as JSON into w.
+	// The Redacted option masks secret-tagged fields rather than writing them in cleartext.
+	SaveJSON(w io.Writer, opts ...Option) error
+	// Dump marshals the whole configuration as JSON.
+	Dump() ([]byte, error)
+	// DumpRedacted marshals the whole configuration as JSON, masking the leaves matching any of the key patterns.
+	DumpRedacted(keys []string) ([]byte, error)
+}
+
+// FromJSON decodes the JSON read from r into the value pointed to by v and wraps it as a configuration.
+// Decoding into a `*map[string]interface{}` results in nested maps, slices and float64 numbers navigable by key.
+func FromJSON(r io.Reader, v interface{}, opts ...Option) (ReadWriter, error) {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return nil, err
+	}
+	return New(v, opts...), nil
+}
+
+// SaveJSON encodes the configuration as JSON into w.
+func (c *config) SaveJSON(w io.Writer, opts ...Option) error {
+	v := c.Data
+	if o := newOptions(opts); o.redacted && v != nil {
+		v = deepCopy(reflect.ValueOf(v), true).Interface()
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// Dump marshals the whole configuration as JSON.
+func (c *config) Dump() ([]byte, error) {
+	return json.Marshal(c.Data)
+}
+
+// DumpRedacted marshals the whole configuration as JSON, masking the leaves matching any of the key patterns.
+// Patterns are matched case-insensitively against the configuration keys, as enumerated by Walk, using path.Match,
+// where `*` may span multiple levels, and mask all leaves below a matching key. Slice elements are matched by their
+// index. Masked strings and values held by interfaces are replaced by `***` while other values are reset to their zero
+// value, composites being masked element by element. Malformed patterns result in path.ErrBadPattern.
+func (c *config) DumpRedacted(keys []string) ([]byte, error) {
+	d := deepCopy(reflect.ValueOf(c.Data), false)
+	var redact visitFunc
+	redact = func(key []string, element reflect.Value, field *reflect.StructField) error {
+		ok, err := matches(key, keys, c.Options.separator)
+		if err != nil {
+			return err
+		}
+		if ok {
+			d = c.maskAt(d, key)
+			return nil
+		}
+		// Slices are leaves of the traversal, their elements being matched by index
+		if e := indirect(element); e.Kind() == reflect.Slice || e.Kind() == reflect.Array {
+			for i := 0; i < e.Len(); i++ {
+				if err := c.visit(append(key[:len(key):len(key)], strconv.Itoa(i)), e.Index(i), nil, redact); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := c.visit(nil, reflect.ValueOf(c.Data), nil, redact); err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if d.IsValid() {
+		v = d.Interface()
+	}
+	return json.Marshal(v)
+}
+
+// maskAt returns the element with the value at the key levels masked, copying the values which can't be set in place.
+func (c *config) maskAt(element reflect.Value, key []string) reflect.Value {
+	if len(key) == 0 {
+		return maskValue(element)
+	}
+	switch element.Kind() {
+	case reflect.Interface:
+		if !element.IsNil() {
+			n := reflect.New(element.Type()).Elem()
+			n.Set(c.maskAt(element.Elem(), key))
+			return n
+		}
+	case reflect.Ptr:
+		if !element.IsNil() {
+			element.Elem().Set(c.maskAt(element.Elem(), key))
+		}
+	case reflect.Struct:
+		if i, ok := structField(element.Type(), key[0], c.Options.caseSensitive); ok {
+			n := reflect.New(element.Type()).Elem()
+			n.Set(element)
+			n.Field(i).Set(c.maskAt(element.Field(i), key[1:]))
+			return n
+		}
+	case reflect.Map:
+		// Levels hold the formatted map keys, which are hence matched exactly
+		if k, found, err := mapKey(element, key[0], true); err == nil && found {
+			element.SetMapIndex(k, c.maskAt(element.MapIndex(k), key[1:]))
+		}
+	case reflect.Slice, reflect.Array:
+		if i, err := strconv.Atoi(key[0]); err == nil && i >= 0 && i < element.Len() {
+			if !element.Index(i).CanSet() {
+				n := reflect.New(element.Type()).Elem()
+				n.Set(element)
+				element = n
+			}
+			element.Index(i).Set(c.maskAt(element.Index(i), key[1:]))
+		}
+	}
+	return element
+}
+
+// maskValue returns the masked copy of an element, composites being masked element by element.
+func maskValue(element reflect.Value) reflect.Value {
+	switch element.Kind() {
+	case reflect.Invalid:
+		return element
+	case reflect.Ptr:
+		if element.IsNil() {
+			return element
+		}
+		p := reflect.New(element.Type().Elem())
+		p.Elem().Set(maskValue(element.Elem()))
+		return p
+	case reflect.Slice:
+		if element.IsNil() {
+			return element
+		}
+		n := reflect.MakeSlice(element.Type(), element.Len(), element.Len())
+		for i := 0; i < element.Len(); i++ {
+			n.Index(i).Set(maskValue(element.Index(i)))
+		}
+		return n
+	case reflect.Array:
+		n := reflect.New(element.Type()).Elem()
+		for i := 0; i < element.Len(); i++ {
+			n.Index(i).Set(maskValue(element.Index(i)))
+		}
+		return n
+	case reflect.Map:
+		if element.IsNil() {
+			return element
+		}
+		n := reflect.MakeMapWithSize(element.Type(), element.Len())
+		i := element.MapRange()
+		for i.Next() {
+			n.SetMapIndex(i.Key(), maskValue(i.Value()))
+		}
+		return n
+	}
+	return masked(element.Type())
+}
+
+// matches reports whether the levels of a key, or of any of its ancestors, match any of the patterns.
+func matches(key []string, patterns []string, sep string) (bool, error) {
+	for i := range key {
+		k := strings.ToLower(strings.Join(key[:i+1], sep))
+		for _, pattern := range patterns {
+			if ok, err := path.Match(strings.ToLower(pattern), k); err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
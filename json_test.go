@@ -0,0 +1,159 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestConfig_SaveJSON(t *testing.T) {
+	type data struct {
+		User     string
+		Password string `config:",secret"`
+	}
+	d := &data{User: "admin", Password: "hunter2"}
+	c := New(d)
+	b := &bytes.Buffer{}
+	if err := c.(Saver).SaveJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"User":"admin","Password":"hunter2"}`
+	if s := strings.TrimSpace(b.String()); s != expected {
+		t.Fatalf("expected %#v, got %#v", expected, s)
+	}
+}
+
+func TestConfig_SaveJSONRedacted(t *testing.T) {
+	type credentials struct {
+		Password string `config:",secret"`
+		Pin      int    `config:",secret"`
+	}
+	type data struct {
+		User        string
+		Credentials map[string]*credentials
+	}
+	d := &data{User: "admin", Credentials: map[string]*credentials{"default": {Password: "hunter2", Pin: 1234}}}
+	c := New(d)
+	b := &bytes.Buffer{}
+	if err := c.(Saver).SaveJSON(b, Redacted()); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"User":"admin","Credentials":{"default":{"Password":"***","Pin":0}}}`
+	if s := strings.TrimSpace(b.String()); s != expected {
+		t.Fatalf("expected %#v, got %#v", expected, s)
+	} else if d.Credentials["default"].Password != "hunter2" {
+		t.Fatalf("expected the original data to be left untouched")
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	d := map[string]interface{}{}
+	c, err := FromJSON(strings.NewReader(`{"server":{"port":8080,"hosts":["a","b"],"tls":{"enabled":true}}}`), &d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := ReadInt(c, "server.port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+	if v, err := ReadBool(c, "server.tls.enabled"); err != nil {
+		t.Fatal(err)
+	} else if !v {
+		t.Fatalf("expected %#v, got %#v", true, v)
+	}
+	for key, value := range map[string]interface{}{"server.tls.cert": "cert.pem", "server.hosts.1": "c", "server.port": 9090.0} {
+		if err := c.Write(key, value); err != nil {
+			t.Fatal(err)
+		}
+		if v, err := c.Read(key); err != nil {
+			t.Fatal(err)
+		} else if v != value {
+			t.Fatalf("expected %#v, got %#v", value, v)
+		}
+	}
+	if _, err := FromJSON(strings.NewReader(`{`), &d); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+func TestConfig_Dump(t *testing.T) {
+	type credentials struct {
+		User     string
+		Password string
+	}
+	type data struct {
+		Name        string
+		Port        int
+		Credentials map[string]*credentials
+		Tokens      []string
+	}
+	d := &data{Name: "api", Port: 8080, Credentials: map[string]*credentials{"db": {User: "admin", Password: "hunter2"}}, Tokens: []string{"a", "b"}}
+	c := New(d).(Saver)
+	if b, err := c.Dump(); err != nil {
+		t.Fatal(err)
+	} else if expected := `{"Name":"api","Port":8080,"Credentials":{"db":{"User":"admin","Password":"hunter2"}},"Tokens":["a","b"]}`; string(b) != expected {
+		t.Fatalf("expected %#v, got %#v", expected, string(b))
+	}
+	if b, err := c.DumpRedacted([]string{"*.password", "tokens", "port"}); err != nil {
+		t.Fatal(err)
+	} else if expected := `{"Name":"api","Port":0,"Credentials":{"db":{"User":"admin","Password":"***"}},"Tokens":["***","***"]}`; string(b) != expected {
+		t.Fatalf("expected %#v, got %#v", expected, string(b))
+	}
+	if d.Credentials["db"].Password != "hunter2" {
+		t.Fatalf("expected the original data to be left untouched")
+	}
+	if _, err := c.DumpRedacted([]string{"["}); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
+
+func TestConfig_DumpRedactedDottedKeys(t *testing.T) {
+	d := map[string]interface{}{"hosts": map[string]interface{}{"example.com": map[string]interface{}{"password": "hunter2"}}}
+	c := New(&d).(Saver)
+	if b, err := c.DumpRedacted([]string{"*password"}); err != nil {
+		t.Fatal(err)
+	} else if expected := `{"hosts":{"example.com":{"password":"***"}}}`; string(b) != expected {
+		t.Fatalf("expected %#v, got %#v", expected, string(b))
+	}
+}
+
+func TestConfig_DumpRedactedSlices(t *testing.T) {
+	d := map[string]interface{}{"servers": []interface{}{map[string]interface{}{"host": "db", "password": "hunter2"}}}
+	c := New(&d).(Saver)
+	for _, pattern := range []string{"*password", "servers.*.password"} {
+		if b, err := c.DumpRedacted([]string{pattern}); err != nil {
+			t.Fatal(err)
+		} else if expected := `{"servers":[{"host":"db","password":"***"}]}`; string(b) != expected {
+			t.Fatalf("expected %#v, got %#v", expected, string(b))
+		}
+	}
+	if d["servers"].([]interface{})[0].(map[string]interface{})["password"] != "hunter2" {
+		t.Fatalf("expected the original data to be left untouched")
+	}
+}
+
+func TestConfig_DumpRedactedTags(t *testing.T) {
+	type database struct {
+		APIKey   string `config:"api_key"`
+		Password string `json:"pw"`
+		Host     string
+	}
+	type data struct {
+		DB database
+	}
+	d := &data{DB: database{APIKey: "k", Password: "hunter2", Host: "db"}}
+	c := New(d).(Saver)
+	if b, err := c.DumpRedacted([]string{"db.api_key", "db.password"}); err != nil {
+		t.Fatal(err)
+	} else if expected := `{"DB":{"APIKey":"***","pw":"***","Host":"db"}}`; string(b) != expected {
+		t.Fatalf("expected %#v, got %#v", expected, string(b))
+	}
+	if d.DB.APIKey != "k" || d.DB.Password != "hunter2" {
+		t.Fatalf("expected the original data to be left untouched")
+	}
+}
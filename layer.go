@@ -0,0 +1,59 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+)
+
+// Layer abstracts Readers as a single Reader where earlier Readers take precedence over later ones.
+//
+// Reads are attempted on each Reader in order, the first result other than an ErrNoSuchKey being returned. This
+// allows for environment-over-file-over-defaults lookups, each layer possibly being a Sub for per-profile overrides.
+func Layer(readers ...Reader) Reader {
+	return newLayered(readers)
+}
+
+// LayerReadWriter behaves like Layer, writes targeting the first Reader which is also a Writer.
+func LayerReadWriter(readers ...Reader) ReadWriter {
+	return newLayered(readers)
+}
+
+// newLayered creates a layered configuration from its Readers.
+func newLayered(readers []Reader) *layered {
+	l := &layered{Readers: readers}
+	l.accessor = accessor{l.Read}
+	return l
+}
+
+// layered is a ReadWriter falling through its Readers until one provides a key.
+type layered struct {
+	accessor
+	Readers []Reader
+}
+
+// Read gets a key's value from the first Reader providing it.
+func (l *layered) Read(key string) (interface{}, error) {
+	var err error = &ErrNoSuchKey{&ConfigurationError{key}}
+	for _, r := range l.Readers {
+		v, rerr := r.Read(key)
+		if errors.Is(rerr, ErrKeyNotFound) {
+			err = rerr
+			continue
+		}
+		return v, rerr
+	}
+	return nil, err
+}
+
+// Write sets a key's value on the first Reader which is also a Writer.
+func (l *layered) Write(key string, v interface{}) error {
+	for _, r := range l.Readers {
+		if w, ok := r.(Writer); ok {
+			return w.Write(key, v)
+		}
+	}
+	return &ErrUnsupported{Operation: "writing", ConfigurationError: &ConfigurationError{key}}
+}
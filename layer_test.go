@@ -0,0 +1,53 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestLayer(t *testing.T) {
+	overrides := map[string]interface{}{"host": "example.com"}
+	defaults := map[string]interface{}{"host": "localhost", "port": 80}
+	c := Layer(New(&overrides), New(&defaults))
+	if v, err := c.ReadString("host"); err != nil {
+		t.Fatal(err)
+	} else if v != "example.com" {
+		t.Fatalf("expected %#v, got %#v", "example.com", v)
+	}
+	if v, err := ReadInt(c, "port"); err != nil {
+		t.Fatal(err)
+	} else if v != 80 {
+		t.Fatalf("expected %#v, got %#v", 80, v)
+	}
+	_, err := c.Read("name")
+	if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	// Errors other than ErrNoSuchKey aren't fallen through
+	_, err = c.Read("host.name")
+	if e, ok := err.(*ErrUnhandledKind); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestLayerReadWriter_Write(t *testing.T) {
+	overrides := map[string]interface{}{}
+	defaults := map[string]interface{}{"port": 80}
+	tracer, _ := NewReadTracer(New(&defaults))
+	c := LayerReadWriter(tracer, New(&overrides), New(&defaults))
+	if err := c.Write("port", 8080); err != nil {
+		t.Fatal(err)
+	} else if overrides["port"] != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, overrides["port"])
+	} else if defaults["port"] != 80 {
+		t.Fatalf("expected %#v, got %#v", 80, defaults["port"])
+	}
+	if err := LayerReadWriter(tracer).Write("port", 8080); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrUnsupported); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_MaxDepth(t *testing.T) {
+	d := map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": "deep"}}}
+	c := New(&d, WithMaxDepth(2))
+	if _, err := c.Read("a.b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Read("a.b.c"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrLimitExceeded); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	if err := c.Write("x.y.z", "deep"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrLimitExceeded); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if _, ok := d["x"]; ok {
+		t.Fatal("expected key to be left unset")
+	}
+	if err := c.(walker).walk("", func(string, reflect.Value, *reflect.StructField) error { return nil }); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrLimitExceeded); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestConfig_MaxKeys(t *testing.T) {
+	d := map[string]interface{}{"a": "a", "b": "b", "c": "c"}
+	from, to := New(&d, WithMaxKeys(3)), New(&map[string]interface{}{})
+	if _, err := Compute(from, to); err != nil {
+		t.Fatal(err)
+	}
+	d["d"] = "d"
+	if _, err := Compute(from, to); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrLimitExceeded); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+)
+
+// Merge deeply merges a source Reader into a destination ReadWriter by writing every leaf of the source into the
+// destination. The source must be enumerable, as are configurations created by New and Sub.
+//
+// Source values override destination values while keys only present in the destination are kept, such that maps and
+// structs are merged recursively. Slices are leaves and are hence replaced as a whole.
+func Merge(dst ReadWriter, src Reader) error {
+	w, ok := src.(walker)
+	if !ok {
+		return &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+	}
+	return w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		v, err := leafValue(key, element)
+		if err != nil {
+			return err
+		}
+		return dst.Write(key, v)
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name    string
+		Servers map[string]server
+		Tags    []string
+	}
+	defaults := &data{
+		Name:    "demo",
+		Servers: map[string]server{"default": {Host: "localhost", Port: 80}},
+		Tags:    []string{"web", "api"},
+	}
+	overrides := map[string]interface{}{
+		"servers": map[string]interface{}{
+			"default": map[string]interface{}{"port": 8080},
+			"backup":  map[string]interface{}{"host": "remote"},
+		},
+		"tags": []string{"db"},
+	}
+	if err := Merge(New(defaults), New(&overrides)); err != nil {
+		t.Fatal(err)
+	}
+	expected := &data{
+		Name:    "demo",
+		Servers: map[string]server{"default": {Host: "localhost", Port: 8080}, "backup": {Host: "remote"}},
+		Tags:    []string{"db"},
+	}
+	if !reflect.DeepEqual(defaults, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, defaults)
+	}
+}
+
+func TestMerge_RegisterType(t *testing.T) {
+	type data struct {
+		Secret rot13
+	}
+	dst := &data{}
+	if err := Merge(New(dst), New(&data{Secret: rot13("Uryyb")})); err != nil {
+		t.Fatal(err)
+	} else if dst.Secret != "Uryyb" {
+		t.Fatalf("expected %#v, got %#v", rot13("Uryyb"), dst.Secret)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"net"
+)
+
+// ReadIP behaves like the Reader's Read with additional IP address conversion taking place.
+// Strings are parsed using net.ParseIP.
+func ReadIP(r Reader, key string) (net.IP, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	ip, kerr := toIP(v)
+	if kerr != nil {
+		kerr.From(key)
+		return nil, kerr
+	}
+	return ip, nil
+}
+
+// ReadCIDR behaves like the Reader's Read with additional CIDR notation conversion taking place.
+// Strings are parsed using net.ParseCIDR.
+func ReadCIDR(r Reader, key string) (*net.IPNet, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	n, kerr := toIPNet(v)
+	if kerr != nil {
+		kerr.From(key)
+		return nil, kerr
+	}
+	return n, nil
+}
+
+// ReadIPNet behaves like ReadCIDR, matching the name of the net.IPNet it returns.
+func ReadIPNet(r Reader, key string) (*net.IPNet, error) {
+	return ReadCIDR(r, key)
+}
+
+// toIP converts a value into an IP address.
+func toIP(v interface{}) (net.IP, KeyError) {
+	switch ip := v.(type) {
+	case net.IP:
+		return ip, nil
+	case *net.IP:
+		if ip != nil {
+			return *ip, nil
+		}
+	case string:
+		if p := net.ParseIP(ip); p != nil {
+			return p, nil
+		}
+	}
+	return nil, &ErrIncompatibleType{Type: "net.IP", ConfigurationError: &ConfigurationError{}}
+}
+
+// toIPNet converts a value into an IP network.
+func toIPNet(v interface{}) (*net.IPNet, KeyError) {
+	switch n := v.(type) {
+	case net.IPNet:
+		return &n, nil
+	case *net.IPNet:
+		if n != nil {
+			return n, nil
+		}
+	case string:
+		if _, p, err := net.ParseCIDR(n); err == nil {
+			return p, nil
+		}
+	}
+	return nil, &ErrIncompatibleType{Type: "*net.IPNet", ConfigurationError: &ConfigurationError{}}
+}
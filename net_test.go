@@ -0,0 +1,94 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"net"
+	"testing"
+)
+
+func TestReadIP(t *testing.T) {
+	type data struct {
+		Native net.IP
+		String string
+		Broken string
+	}
+	d := &data{Native: net.IPv4(192, 0, 2, 1), String: "2001:db8::1", Broken: "localhost"}
+	c := New(d)
+	if ip, err := ReadIP(c, "native"); err != nil {
+		t.Fatal(err)
+	} else if !ip.Equal(d.Native) {
+		t.Fatalf("expected %#v, got %#v", d.Native.String(), ip.String())
+	}
+	if ip, err := ReadIP(c, "string"); err != nil {
+		t.Fatal(err)
+	} else if !ip.Equal(net.ParseIP(d.String)) {
+		t.Fatalf("expected %#v, got %#v", d.String, ip.String())
+	}
+	if _, err := ReadIP(c, "broken"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestReadCIDR(t *testing.T) {
+	d := map[string]interface{}{"network": "192.0.2.0/24", "broken": "192.0.2.0"}
+	c := New(&d)
+	if n, err := ReadCIDR(c, "network"); err != nil {
+		t.Fatal(err)
+	} else if s := n.String(); s != d["network"] {
+		t.Fatalf("expected %#v, got %#v", d["network"], s)
+	}
+	if _, err := ReadCIDR(c, "broken"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestConfig_WriteIP(t *testing.T) {
+	type data struct {
+		IP net.IP
+	}
+	d := &data{}
+	c := New(d)
+	ip := "192.0.2.1"
+	if err := c.Write("ip", ip); err != nil {
+		t.Fatal(err)
+	} else if !d.IP.Equal(net.ParseIP(ip)) {
+		t.Fatalf("expected %#v, got %#v", ip, d.IP.String())
+	}
+	if err := c.Write("ip", "localhost"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "ip" {
+		t.Fatalf("expected %#v, got %#v", "ip", e.Key())
+	}
+}
+
+func TestReadIPNet(t *testing.T) {
+	_, native, _ := net.ParseCIDR("2001:db8::/32")
+	d := map[string]interface{}{"native": native, "network": "10.0.0.0/8", "broken": "10.0.0.0/33"}
+	c := New(&d)
+	if n, err := ReadIPNet(c, "native"); err != nil {
+		t.Fatal(err)
+	} else if n != native {
+		t.Fatalf("expected %#v, got %#v", native.String(), n.String())
+	}
+	if n, err := ReadIPNet(c, "network"); err != nil {
+		t.Fatal(err)
+	} else if s := n.String(); s != d["network"] {
+		t.Fatalf("expected %#v, got %#v", d["network"], s)
+	}
+	if _, err := ReadIPNet(c, "broken"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "broken" {
+		t.Fatalf("expected %#v, got %#v", "broken", e.Key())
+	}
+}
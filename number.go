@@ -0,0 +1,223 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// toInt64 converts a value into a 64-bit integer.
+// Floats are only converted when integral while strings are parsed as base-10 integers.
+func toInt64(v interface{}) (int64, KeyError) {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := val.Uint(); u <= math.MaxInt64 {
+			return int64(u), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := val.Float(); f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return int64(f), nil
+		}
+	case reflect.String:
+		if i, err := strconv.ParseInt(strings.TrimSpace(val.String()), 10, 64); err == nil {
+			return i, nil
+		}
+	}
+	return 0, &ErrIncompatibleType{Type: "int64", ConfigurationError: &ConfigurationError{}}
+}
+
+// toUint64 converts a value into a 64-bit unsigned integer, negative values resulting in an ErrInvalidValue wrapping
+// the strconv.ErrRange *strconv.NumError. Floats are only converted when integral while strings are parsed as base-10
+// unsigned integers.
+func toUint64(v interface{}) (uint64, KeyError) {
+	val := reflect.ValueOf(v)
+	negative := false
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i := val.Int(); i >= 0 {
+			return uint64(i), nil
+		}
+		negative = true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return val.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		if f := val.Float(); f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 {
+			return uint64(f), nil
+		} else if f < 0 {
+			negative = true
+		}
+	case reflect.String:
+		u, err := strconv.ParseUint(strings.TrimSpace(val.String()), 10, 64)
+		if err == nil {
+			return u, nil
+		} else if errors.Is(err, strconv.ErrRange) {
+			return 0, &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+		}
+	}
+	if negative {
+		return 0, &ErrInvalidValue{Err: &strconv.NumError{Func: "ParseUint", Num: fmt.Sprint(v), Err: strconv.ErrRange}, ConfigurationError: &ConfigurationError{}}
+	}
+	return 0, &ErrIncompatibleType{Type: "uint64", ConfigurationError: &ConfigurationError{}}
+}
+
+// toInt converts a value into an integer, values which don't fit an int resulting in an ErrInvalidValue wrapping the
+// strconv.ErrRange *strconv.NumError.
+func toInt(v interface{}) (int, KeyError) {
+	if s, ok := v.(string); ok {
+		i, err := strconv.ParseInt(strings.TrimSpace(s), 10, strconv.IntSize)
+		if err != nil {
+			if errors.Is(err, strconv.ErrRange) {
+				return 0, &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{}}
+			}
+			return 0, &ErrIncompatibleType{Type: "int", ConfigurationError: &ConfigurationError{}}
+		}
+		return int(i), nil
+	}
+	i, err := toInt64(v)
+	switch {
+	case err != nil && reflect.ValueOf(v).Kind() >= reflect.Uint && reflect.ValueOf(v).Kind() <= reflect.Uintptr:
+		// Unsigned integers only fail to convert when overflowing
+		fallthrough
+	case i < math.MinInt || i > math.MaxInt:
+		return 0, &ErrInvalidValue{Err: &strconv.NumError{Func: "ParseInt", Num: fmt.Sprint(v), Err: strconv.ErrRange}, ConfigurationError: &ConfigurationError{}}
+	case err != nil:
+		return 0, &ErrIncompatibleType{Type: "int", ConfigurationError: &ConfigurationError{}}
+	}
+	return int(i), nil
+}
+
+// toFloat64 converts a value into a 64-bit float. Strings are parsed using strconv.ParseFloat.
+func toFloat64(v interface{}) (float64, KeyError) {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(val.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), nil
+	case reflect.String:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(val.String()), 64); err == nil {
+			return f, nil
+		}
+	}
+	return 0, &ErrIncompatibleType{Type: "float64", ConfigurationError: &ConfigurationError{}}
+}
+
+// byteSizes holds the multiplier of each lowercased byte size suffix.
+var byteSizes = map[string]float64{
+	"":    1,
+	"b":   1,
+	"k":   1e3,
+	"kb":  1e3,
+	"kib": 1 << 10,
+	"m":   1e6,
+	"mb":  1e6,
+	"mib": 1 << 20,
+	"g":   1e9,
+	"gb":  1e9,
+	"gib": 1 << 30,
+	"t":   1e12,
+	"tb":  1e12,
+	"tib": 1 << 40,
+	"p":   1e15,
+	"pb":  1e15,
+	"pib": 1 << 50,
+	"e":   1e18,
+	"eb":  1e18,
+	"eib": 1 << 60,
+}
+
+// toByteSize converts a value into a number of bytes.
+// Numbers are returned as-is while strings such as `10MB` or `1.5 GiB` are parsed, suffixes being case-insensitive.
+// Decimal suffixes (kB, MB, GB, TB, PB, EB) are powers of 1000 while binary ones (KiB, MiB, GiB, TiB, PiB, EiB)
+// are powers of 1024. A lone unit letter (k, M, G, ...) is decimal and a lack of suffix or `B` denotes bytes.
+func toByteSize(v interface{}) (int64, KeyError) {
+	s, ok := v.(string)
+	if !ok {
+		return toInt64(v)
+	}
+	s = strings.TrimSpace(s)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	n, err := strconv.ParseFloat(s[:i], 64)
+	m, ok := byteSizes[strings.ToLower(strings.TrimSpace(s[i:]))]
+	if err != nil || !ok || n*m >= math.MaxInt64 {
+		return 0, &ErrIncompatibleType{Type: "byte size", ConfigurationError: &ConfigurationError{}}
+	}
+	return int64(math.Round(n * m)), nil
+}
+
+// ReadInt behaves like the Reader's Read with additional integer conversion taking place.
+// Numeric strings are parsed while values overflowing an int result in an ErrInvalidValue.
+func ReadInt(r Reader, key string) (int, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	i, kerr := toInt(v)
+	if kerr != nil {
+		kerr.From(key)
+		return 0, kerr
+	}
+	return i, nil
+}
+
+// ReadInt64 behaves like the Reader's Read with additional 64-bit integer conversion taking place, regardless of the
+// platform's int size. Numeric strings are parsed as base-10 integers.
+func ReadInt64(r Reader, key string) (int64, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	i, kerr := toInt64(v)
+	if kerr != nil {
+		kerr.From(key)
+		return 0, kerr
+	}
+	return i, nil
+}
+
+// ReadUint64 behaves like the Reader's Read with additional 64-bit unsigned integer conversion taking place. Numeric
+// strings are parsed as base-10 unsigned integers while negative values result in an ErrInvalidValue.
+func ReadUint64(r Reader, key string) (uint64, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	u, kerr := toUint64(v)
+	if kerr != nil {
+		kerr.From(key)
+		return 0, kerr
+	}
+	return u, nil
+}
+
+// ReadFloat64 behaves like the Reader's Read with additional float conversion taking place.
+// Integers are converted into their float equivalent while numeric strings are parsed.
+func ReadFloat64(r Reader, key string) (float64, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return 0, err
+	}
+	f, kerr := toFloat64(v)
+	if kerr != nil {
+		kerr.From(key)
+		return 0, kerr
+	}
+	return f, nil
+}
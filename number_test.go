@@ -0,0 +1,167 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestReadByteSize(t *testing.T) {
+	tests := map[interface{}]int64{
+		4096:      4096,
+		"512":     512,
+		"512B":    512,
+		"10k":     10000,
+		"10KB":    10000,
+		"10KiB":   10240,
+		"10MB":    10000000,
+		"10MiB":   10485760,
+		"1.5 GB":  1500000000,
+		"2GiB":    2147483648,
+		"1tb":     1000000000000,
+		"1TiB":    1099511627776,
+		"1PB":     1000000000000000,
+		"1PiB":    1125899906842624,
+		"1EB":     1000000000000000000,
+		"0.5EiB":  576460752303423488,
+		" 64 mb ": 64000000,
+	}
+	for v, expected := range tests {
+		c := New(&map[string]interface{}{"size": v})
+		if n, err := ReadByteSize(c, "size"); err != nil {
+			t.Fatal(err)
+		} else if n != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, v, n)
+		}
+	}
+	for _, v := range []interface{}{"10XB", "MB", "1.2.3MB", "16EiB", true} {
+		c := New(&map[string]interface{}{"size": v})
+		if _, err := ReadByteSize(c, "size"); err == nil {
+			t.Fatalf("expected error for %#v but got none", v)
+		} else if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		}
+	}
+}
+
+func TestReadInt(t *testing.T) {
+	tests := map[interface{}]int{
+		42:             42,
+		int8(-8):       -8,
+		uint16(16):     16,
+		int64(1 << 40): 1 << 40,
+		4.0:            4,
+		"123":          123,
+		" -7 ":         -7,
+	}
+	for v, expected := range tests {
+		c := New(&map[string]interface{}{"count": v})
+		if n, err := ReadInt(c, "count"); err != nil {
+			t.Fatal(err)
+		} else if n != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, v, n)
+		}
+	}
+	for _, v := range []interface{}{"ten", 4.5, struct{}{}, true} {
+		c := New(&map[string]interface{}{"count": v})
+		_, err := ReadInt(c, "count")
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error for %#v, got %T error", e, v, err)
+		} else if e.Key() != "count" {
+			t.Fatalf("expected %#v, got %#v", "count", e.Key())
+		}
+	}
+	for _, v := range []interface{}{"99999999999999999999", uint64(math.MaxUint64)} {
+		c := New(&map[string]interface{}{"count": v})
+		_, err := ReadInt(c, "count")
+		if e, ok := err.(*ErrInvalidValue); !ok {
+			t.Fatalf("expected %T error for %#v, got %T error", e, v, err)
+		} else if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("expected %#v, got %#v", strconv.ErrRange, e.Err)
+		}
+	}
+}
+
+func TestReadFloat64(t *testing.T) {
+	tests := map[interface{}]float64{
+		0.25:         0.25,
+		float32(0.5): 0.5,
+		3:            3,
+		uint8(7):     7,
+		"1.5":        1.5,
+		" 2e3 ":      2000,
+	}
+	for v, expected := range tests {
+		c := New(&map[string]interface{}{"ratio": v})
+		if f, err := ReadFloat64(c, "ratio"); err != nil {
+			t.Fatal(err)
+		} else if f != expected {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, v, f)
+		}
+	}
+	for _, v := range []interface{}{"half", true, struct{}{}} {
+		c := New(&map[string]interface{}{"ratio": v})
+		_, err := ReadFloat64(c, "ratio")
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error for %#v, got %T error", e, v, err)
+		} else if e.Key() != "ratio" {
+			t.Fatalf("expected %#v, got %#v", "ratio", e.Key())
+		}
+	}
+}
+
+func TestReadInt64(t *testing.T) {
+	d := map[string]interface{}{"id": uint32(1 << 31), "big": "9007199254740993", "float": 42.0, "broken": "4.2"}
+	c := New(&d)
+	for key, expected := range map[string]int64{"id": 1 << 31, "big": 9007199254740993, "float": 42} {
+		if v, err := ReadInt64(c, key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	_, err := ReadInt64(c, "broken")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "broken" {
+		t.Fatalf("expected %#v, got %#v", "broken", e.Key())
+	}
+}
+
+func TestReadUint64(t *testing.T) {
+	d := map[string]interface{}{
+		"max":      uint64(math.MaxUint64),
+		"string":   "18446744073709551615",
+		"int":      42,
+		"negative": -1,
+		"overflow": "18446744073709551616",
+		"broken":   "many",
+	}
+	c := New(&d)
+	for key, expected := range map[string]uint64{"max": math.MaxUint64, "string": math.MaxUint64, "int": 42} {
+		if v, err := ReadUint64(c, key); err != nil {
+			t.Fatal(err)
+		} else if v != expected {
+			t.Fatalf("expected %#v, got %#v", expected, v)
+		}
+	}
+	for _, key := range []string{"negative", "overflow"} {
+		_, err := ReadUint64(c, key)
+		if e, ok := err.(*ErrInvalidValue); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("expected %#v, got %#v", strconv.ErrRange, e.Err)
+		} else if e.Key() != key {
+			t.Fatalf("expected %#v, got %#v", key, e.Key())
+		}
+	}
+	_, err := ReadUint64(c, "broken")
+	if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"sync"
+)
+
+// ObserverFunc is called after every successful write with the key's previous and new value.
+// The previous value is nil when the key didn't exist.
+type ObserverFunc func(key string, old, new interface{})
+
+// ChangeEvent describes a successful write of a key from its previous to its new value.
+type ChangeEvent struct {
+	Key string
+	Old interface{}
+	New interface{}
+}
+
+// Change describes a write which altered a key's value, as emitted by Observable.
+type Change = ChangeEvent
+
+// ObservableReadWriter abstracts a ReadWriter whose successful writes are observable.
+type ObservableReadWriter interface {
+	ReadWriter
+	// Events returns the channel on which a ChangeEvent is emitted for every successful write. Events are only
+	// emitted once the channel has been requested.
+	Events() <-chan ChangeEvent
+	// Close stops the emission of events and closes the events channel.
+	Close() error
+}
+
+// NewObservable abstracts a ReadWriter notifying observers of every successful write, either through the functions
+// registered WithObserver or through its Events channel.
+//
+// The events channel is buffered as set by WithEventBuffer. Once full, writes block until the consumer catches up
+// unless the DropEvents option discards the overflowing events.
+//
+// The previous value of a map, slice, pointer or struct is deep-copied before the write, so that observers receive a
+// snapshot of the prior state even when the written value shares its structure.
+func NewObservable(rw ReadWriter, opts ...Option) ObservableReadWriter {
+	return &observable{ReadWriter: rw, Options: newOptions(opts), done: make(chan struct{})}
+}
+
+// Observable abstracts a ReadWriter emitting a Change on the returned channel for every successful write which altered
+// a key's value, writes leaving the value unchanged emitting nothing.
+//
+// The channel is buffered and closed like the NewObservable events channel, for which the same options apply. The
+// WithObserver option provides a callback alternative to consuming the channel.
+func Observable(rw ReadWriter, opts ...Option) (ReadWriter, <-chan Change) {
+	o := &observable{ReadWriter: rw, Options: newOptions(opts), ChangesOnly: true, done: make(chan struct{})}
+	return o, o.Events()
+}
+
+// observable is a ReadWriter notifying observers of every successful write.
+type observable struct {
+	ReadWriter
+	Options options
+	// ChangesOnly skips the notifications of writes leaving the value unchanged.
+	ChangesOnly bool
+	events      chan ChangeEvent
+	// done is closed by Close to interrupt the blocked sends awaited through sending.
+	done    chan struct{}
+	sending sync.WaitGroup
+	closed  bool
+	mu      sync.RWMutex
+}
+
+// Write is a notifying wrapper around the Writer.
+func (o *observable) Write(key string, v interface{}) error {
+	old, err := o.ReadWriter.Read(key)
+	if err != nil {
+		old = nil
+	}
+	old = snapshot(old)
+	if err := o.ReadWriter.Write(key, v); err != nil {
+		return err
+	}
+	n, err := o.ReadWriter.Read(key)
+	if err != nil {
+		n = v
+	}
+	if o.ChangesOnly && reflect.DeepEqual(old, n) {
+		return nil
+	}
+	for _, fn := range o.Options.observers {
+		fn(key, old, n)
+	}
+	o.emit(ChangeEvent{Key: key, Old: old, New: n})
+	return nil
+}
+
+// emit sends the event on the events channel if any, respecting the drop policy.
+// The lock isn't held while sending, such that Close can interrupt a send blocked on a full channel.
+func (o *observable) emit(e ChangeEvent) {
+	o.mu.RLock()
+	if o.events == nil || o.closed {
+		o.mu.RUnlock()
+		return
+	}
+	o.sending.Add(1)
+	defer o.sending.Done()
+	o.mu.RUnlock()
+	if o.Options.dropEvents {
+		select {
+		case o.events <- e:
+		default:
+		}
+		return
+	}
+	select {
+	case o.events <- e:
+	case <-o.done:
+	}
+}
+
+// Events returns the channel on which a ChangeEvent is emitted for every successful write.
+func (o *observable) Events() <-chan ChangeEvent {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.events == nil {
+		o.events = make(chan ChangeEvent, o.Options.eventBuffer)
+		if o.closed {
+			close(o.events)
+		}
+	}
+	return o.events
+}
+
+// Close stops the emission of events and closes the events channel.
+// Sends blocked on a full channel are interrupted, their events being discarded.
+func (o *observable) Close() error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return nil
+	}
+	o.closed = true
+	close(o.done)
+	events := o.events
+	o.mu.Unlock()
+	// Only close the channel once the interrupted sends returned
+	o.sending.Wait()
+	if events != nil {
+		close(events)
+	}
+	return nil
+}
+
+// snapshot deep-copies composite values, leaving other values untouched.
+func snapshot(v interface{}) interface{} {
+	e := reflect.ValueOf(v)
+	switch e.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr, reflect.Struct:
+		return deepCopy(e, false).Interface()
+	default:
+		return v
+	}
+}
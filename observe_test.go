@@ -0,0 +1,132 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewObservable_Write(t *testing.T) {
+	labels := map[string]interface{}{"env": "dev"}
+	d := map[string]interface{}{"labels": labels}
+	var keys []string
+	var olds, news []interface{}
+	c := NewObservable(New(&d), WithObserver(func(key string, old, new interface{}) {
+		keys = append(keys, key)
+		olds = append(olds, old)
+		news = append(news, new)
+	}))
+	if err := c.Write("labels", map[string]interface{}{"env": "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	// Mutating the previous map must not affect the observed snapshot
+	labels["env"] = "modified"
+	if err := c.Write("name", "demo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Write("name.first", "demo"); err == nil {
+		t.Fatal("expected error but got none")
+	}
+	if expected := []string{"labels", "name"}; !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+	if expected := []interface{}{map[string]interface{}{"env": "dev"}, nil}; !reflect.DeepEqual(olds, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, olds)
+	}
+	if expected := []interface{}{map[string]interface{}{"env": "prod"}, "demo"}; !reflect.DeepEqual(news, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, news)
+	}
+}
+
+func TestNewObservable_Events(t *testing.T) {
+	d := map[string]interface{}{"name": "demo"}
+	c := NewObservable(New(&d))
+	events := c.Events()
+	if err := c.Write("name", "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Write("port", 8080); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Write("name", "second"); err != nil {
+		t.Fatal(err)
+	}
+	var received []ChangeEvent
+	for e := range events {
+		received = append(received, e)
+	}
+	expected := []ChangeEvent{{Key: "name", Old: "demo", New: "first"}, {Key: "port", New: 8080}}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, received)
+	}
+}
+
+func TestNewObservable_DropEvents(t *testing.T) {
+	d := map[string]interface{}{}
+	c := NewObservable(New(&d), WithEventBuffer(1), DropEvents())
+	events := c.Events()
+	for _, v := range []string{"first", "second"} {
+		if err := c.Write("name", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.Close()
+	var received []ChangeEvent
+	for e := range events {
+		received = append(received, e)
+	}
+	expected := []ChangeEvent{{Key: "name", New: "first"}}
+	if !reflect.DeepEqual(received, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, received)
+	}
+}
+
+func TestObservable(t *testing.T) {
+	d := map[string]interface{}{"port": 80}
+	rw, changes := Observable(New(&d))
+	for _, port := range []int{80, 8080, 8080, 443} {
+		if err := rw.Write("port", port); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := rw.Write("port.number", 1); err == nil {
+		t.Fatal("expected error but got none")
+	}
+	for _, expected := range []Change{{Key: "port", Old: 80, New: 8080}, {Key: "port", Old: 8080, New: 443}} {
+		if c := <-changes; c != expected {
+			t.Fatalf("expected %#v, got %#v", expected, c)
+		}
+	}
+	select {
+	case c := <-changes:
+		t.Fatalf("unexpected %#v change", c)
+	default:
+	}
+}
+
+func TestNewObservable_CloseBlocked(t *testing.T) {
+	d := map[string]interface{}{}
+	emitting := make(chan struct{})
+	c := NewObservable(New(&d), WithEventBuffer(0), WithObserver(func(key string, old, new interface{}) {
+		close(emitting)
+	}))
+	c.Events()
+	written := make(chan error)
+	go func() {
+		written <- c.Write("name", "demo")
+	}()
+	// Close while the write blocks on the unconsumed channel
+	<-emitting
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-written; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+// Option alters the behaviour of a configuration or of a single call.
+type Option func(*options)
+
+// options holds the settings altered by an Option.
+type options struct {
+	redacted         bool
+	keepUnresolved   bool
+	transforms       []func(key string, v interface{}) (interface{}, error)
+	skipIncompatible bool
+	noCoercion       bool
+	normalize        func(key string) string
+	maxDepth         int
+	maxKeys          int
+	base64           bool
+	prototype        func() interface{}
+	observers        []ObserverFunc
+	eventBuffer      int
+	dropEvents       bool
+	separator        string
+	strictNumbers    bool
+	caseSensitive    bool
+}
+
+// newOptions applies the provided options over the defaults.
+func newOptions(opts []Option) options {
+	o := options{eventBuffer: 16, separator: "."}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Redacted masks the fields tagged with the `secret` option, for example `config:",secret"`, or omits them where
+// masking is not applicable.
+func Redacted() Option {
+	return func(o *options) {
+		o.redacted = true
+	}
+}
+
+// KeepUnresolved leaves references to unknown keys untouched when resolving rather than replacing them by an empty string.
+func KeepUnresolved() Option {
+	return func(o *options) {
+		o.keepUnresolved = true
+	}
+}
+
+// WithWriteTransform transforms every written value before it is stored, allowing for normalization such as trimming.
+// The transformation is provided the written key and rejects the value by returning an error.
+// Multiple transformations are applied in the order they are provided.
+func WithWriteTransform(fn func(key string, v interface{}) (interface{}, error)) Option {
+	return func(o *options) {
+		o.transforms = append(o.transforms, fn)
+	}
+}
+
+// SkipIncompatible continues with the next layer when a layered value can't be converted rather than failing.
+func SkipIncompatible() Option {
+	return func(o *options) {
+		o.skipIncompatible = true
+	}
+}
+
+// WithNoCoercion only allows writing values assignable to their destination, rejecting conversions such as float64 to int.
+func WithNoCoercion() Option {
+	return func(o *options) {
+		o.noCoercion = true
+	}
+}
+
+// WithNormalizedMapKeys normalizes the keys of newly created map entries, for example using strings.ToLower.
+// Existing entries keep matching case-insensitively, preventing a map from accumulating both `Foo` and `foo`.
+func WithNormalizedMapKeys(fn func(key string) string) Option {
+	return func(o *options) {
+		o.normalize = fn
+	}
+}
+
+// WithMaxDepth limits the number of levels keys may have, protecting against pathological inputs.
+// Reading, writing or enumerating beyond the limit results in an ErrLimitExceeded.
+func WithMaxDepth(n int) Option {
+	return func(o *options) {
+		o.maxDepth = n
+	}
+}
+
+// WithMaxKeys limits the number of keys an enumeration may provide, protecting against pathological inputs.
+// Enumerating more keys results in an ErrLimitExceeded.
+func WithMaxKeys(n int) Option {
+	return func(o *options) {
+		o.maxKeys = n
+	}
+}
+
+// WithBase64Bytes decodes the strings written into byte slices, such as `[]byte` fields holding binary blobs, using
+// standard base64 rather than casting them. Strings which aren't valid base64 result in an ErrIncompatibleType.
+func WithBase64Bytes() Option {
+	return func(o *options) {
+		o.base64 = true
+	}
+}
+
+// WithPrototype initializes the structs created as new map entries from a deep copy of the prototype returned by fn,
+// before the nested write is applied. Writing `servers.backup.port` hence creates a `backup` server holding the
+// prototype's values with only its port replaced. Prototypes whose type doesn't match the map's value are ignored.
+func WithPrototype(fn func() interface{}) Option {
+	return func(o *options) {
+		o.prototype = fn
+	}
+}
+
+// WithObserver registers a function called by NewObservable after every successful write.
+func WithObserver(fn ObserverFunc) Option {
+	return func(o *options) {
+		o.observers = append(o.observers, fn)
+	}
+}
+
+// WithEventBuffer sets the capacity of the NewObservable events channel, which defaults to 16.
+func WithEventBuffer(n int) Option {
+	return func(o *options) {
+		o.eventBuffer = n
+	}
+}
+
+// DropEvents discards the NewObservable events which don't fit the channel's buffer rather than blocking the writer
+// until the consumer catches up.
+func DropEvents() Option {
+	return func(o *options) {
+		o.dropEvents = true
+	}
+}
+
+// withSeparator separates key levels by sep rather than a dot.
+func withSeparator(sep string) Option {
+	return func(o *options) {
+		o.separator = sep
+	}
+}
+
+// withStrictNumbers rejects numeric conversions into integers which don't preserve the written value.
+func withStrictNumbers() Option {
+	return func(o *options) {
+		o.strictNumbers = true
+	}
+}
+
+// withCaseSensitive matches keys exactly rather than case-insensitively.
+func withCaseSensitive() Option {
+	return func(o *options) {
+		o.caseSensitive = true
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"sort"
+)
+
+const (
+	// OpSet is the Operation writing a key's value.
+	OpSet = "set"
+	// OpDelete is the Operation removing a key.
+	OpDelete = "delete"
+)
+
+// Operation is a single change of a Patch.
+type Operation struct {
+	Op    string      `json:"op"`
+	Key   string      `json:"key"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Patch is an ordered list of operations transforming a configuration into another.
+// A Patch can be serialized as JSON for storage or transmission.
+type Patch []Operation
+
+// Compute produces the Patch transforming the from configuration into the to configuration.
+//
+// Both readers must be enumerable, as are configurations created by New and Sub. The Patch first removes the keys
+// absent from to before setting all changed or added keys, each in key order.
+func Compute(from, to Reader) (Patch, error) {
+	f, err := leaves(from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := leaves(to)
+	if err != nil {
+		return nil, err
+	}
+	var deletes, sets Patch
+	for k, v := range f {
+		if _, ok := t[k]; !ok {
+			deletes = append(deletes, Operation{Op: OpDelete, Key: v.Key})
+		}
+	}
+	for k, v := range t {
+		if o, ok := f[k]; !ok || !reflect.DeepEqual(o.Value, v.Value) {
+			sets = append(sets, Operation{Op: OpSet, Key: v.Key, Value: v.Value})
+		}
+	}
+	sort.Slice(deletes, func(i, j int) bool { return deletes[i].Key < deletes[j].Key })
+	sort.Slice(sets, func(i, j int) bool { return sets[i].Key < sets[j].Key })
+	return append(deletes, sets...), nil
+}
+
+// leaves enumerates the leaf values of a Reader, indexed by their key as matched by the Reader.
+func leaves(r Reader) (map[string]Operation, error) {
+	w, ok := r.(walker)
+	if !ok {
+		return nil, &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+	}
+	l := map[string]Operation{}
+	err := w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		v, err := leafValue(key, element)
+		if err != nil {
+			return err
+		}
+		l[foldKey(r, key)] = Operation{Key: key, Value: v}
+		return nil
+	})
+	return l, err
+}
+
+// Apply applies the Patch operations in order, deletions requiring the ReadWriter to implement Delete.
+//
+// Apply is transactional on a best-effort basis: when an operation fails, the already applied operations are reverted
+// in reverse order before the error is returned.
+func (p Patch) Apply(rw ReadWriter) error {
+	type undo struct {
+		Key     string
+		Value   interface{}
+		Existed bool
+	}
+	var applied []undo
+	for _, op := range p {
+		u := undo{Key: op.Key}
+		if v, err := rw.Read(op.Key); err == nil {
+			u.Value, u.Existed = v, true
+		}
+		err := apply(rw, op)
+		if err != nil {
+			for i := len(applied) - 1; i >= 0; i-- {
+				if a := applied[i]; a.Existed {
+					_ = rw.Write(a.Key, a.Value)
+				} else {
+					_ = apply(rw, Operation{Op: OpDelete, Key: a.Key})
+				}
+			}
+			return err
+		}
+		applied = append(applied, u)
+	}
+	return nil
+}
+
+// apply applies a single Operation.
+func apply(rw ReadWriter, op Operation) error {
+	switch op.Op {
+	case OpSet:
+		return rw.Write(op.Key, op.Value)
+	case OpDelete:
+		d, ok := rw.(Deleter)
+		if !ok {
+			return &ErrUnsupported{Operation: "deletion", ConfigurationError: &ConfigurationError{op.Key}}
+		}
+		return d.Delete(op.Key)
+	default:
+		return &ErrUnsupported{Operation: op.Op, ConfigurationError: &ConfigurationError{op.Key}}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCompute(t *testing.T) {
+	from := map[string]interface{}{"host": "localhost", "port": 80, "debug": true}
+	to := map[string]interface{}{"host": "example.com", "port": 80, "name": "demo"}
+	p, err := Compute(New(&from), New(&to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Patch{
+		{Op: OpDelete, Key: "debug"},
+		{Op: OpSet, Key: "host", Value: "example.com"},
+		{Op: OpSet, Key: "name", Value: "demo"},
+	}
+	if !reflect.DeepEqual(p, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, p)
+	}
+}
+
+func TestPatch_Apply(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name    string
+		Servers map[string]server
+	}
+	from := &data{Name: "demo", Servers: map[string]server{"default": {Host: "localhost", Port: 80}}}
+	to := &data{Name: "prod", Servers: map[string]server{"default": {Host: "localhost", Port: 443}, "backup": {Host: "remote", Port: 443}}}
+	p, err := Compute(New(from), New(to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Round-trip the patch through JSON
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var q Patch
+	if err := json.Unmarshal(b, &q); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Apply(New(from)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(from, to) {
+		t.Fatalf("expected %#v, got %#v", to, from)
+	}
+}
+
+func TestPatch_ApplyRollback(t *testing.T) {
+	type data struct {
+		Name string
+		Port int
+	}
+	d := &data{Name: "demo", Port: 80}
+	p := Patch{
+		{Op: OpSet, Key: "name", Value: "prod"},
+		{Op: OpSet, Key: "port", Value: []string{"invalid"}},
+	}
+	if err := p.Apply(New(d)); err == nil {
+		t.Fatal("expected error but got none")
+	} else if d.Name != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", d.Name)
+	}
+}
+
+func TestPatch_ApplyDelete(t *testing.T) {
+	from := map[string]interface{}{"host": "localhost", "debug": true}
+	to := map[string]interface{}{"host": "localhost"}
+	p, err := Compute(New(&from), New(&to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Apply(New(&from)); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(from, to) {
+		t.Fatalf("expected %#v, got %#v", to, from)
+	}
+}
+
+func TestPatch_ApplyRegisterType(t *testing.T) {
+	type data struct {
+		Secret rot13
+	}
+	from := &data{Secret: rot13("Uryyb")}
+	to := &data{Secret: rot13("Jbeyq")}
+	p, err := Compute(New(from), New(to))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Patch{{Op: OpSet, Key: "secret", Value: "World"}}); !reflect.DeepEqual(p, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, p)
+	}
+	if err := p.Apply(New(from)); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(from, to) {
+		t.Fatalf("expected %#v, got %#v", to, from)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"reflect"
+)
+
+// PathValue is the value of a partial key along a path.
+type PathValue struct {
+	Key   string
+	Value interface{}
+}
+
+// PathReader abstracts a configuration able to trace how a key resolves.
+type PathReader interface {
+	// ReadPath gets the value of every partial key along a key's path, such that `a.b.c` provides the values at `a`,
+	// `a.b` and `a.b.c`. On failure, the values up to the failing level are provided alongside the error.
+	ReadPath(key string) ([]PathValue, error)
+}
+
+// ReadPath gets the value of every partial key along a key's path.
+func (c *config) ReadPath(key string) ([]PathValue, error) {
+	k, err := c.split(key)
+	if err != nil {
+		return nil, err
+	}
+	e := reflect.ValueOf(c.Data)
+	path := make([]PathValue, 0, len(k))
+	for i := range k {
+		e, err = c.read(context.Background(), k[i:i+1], e)
+		if err != nil {
+			if i > 0 {
+				c.from(err, c.join(k[:i]))
+			}
+			return path, err
+		}
+		var v interface{}
+		if e.IsValid() {
+			v = e.Interface()
+		}
+		path = append(path, PathValue{Key: c.join(k[:i+1]), Value: v})
+	}
+	return path, nil
+}
+
+// ReadPath is a prefixed wrapper around the PathReader, only providing the values below the prefix.
+func (s *subReader) ReadPath(key string) ([]PathValue, error) {
+	p, ok := s.R.(PathReader)
+	if !ok {
+		return nil, &ErrUnsupported{Operation: "path tracing", ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	path, err := p.ReadPath(s.resolve(key))
+	var rel []PathValue
+	for _, v := range path {
+		if k, ok := s.relative(v.Key); ok {
+			rel = append(rel, PathValue{Key: k, Value: v.Value})
+		}
+	}
+	return rel, err
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_ReadPath(t *testing.T) {
+	type server struct {
+		Host string
+	}
+	type data struct {
+		Servers map[string]server
+	}
+	d := &data{Servers: map[string]server{"default": {Host: "localhost"}}}
+	c := New(d).(PathReader)
+	expected := []PathValue{
+		{Key: "servers", Value: d.Servers},
+		{Key: "servers.default", Value: d.Servers["default"]},
+		{Key: "servers.default.host", Value: "localhost"},
+	}
+	if path, err := c.ReadPath("servers.default.host"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(path, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, path)
+	}
+	path, err := c.ReadPath("servers.backup.host")
+	if err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "servers.backup" {
+		t.Fatalf("expected %#v, got %#v", "servers.backup", e.Key())
+	} else if !reflect.DeepEqual(path, expected[:1]) {
+		t.Fatalf("expected %#v, got %#v", expected[:1], path)
+	}
+}
+
+func TestSub_ReadPath(t *testing.T) {
+	d := map[string]interface{}{"profiles": map[string]interface{}{"default": map[string]interface{}{"name": "demo"}}}
+	c := Sub(New(&d), "profiles.default").(PathReader)
+	expected := []PathValue{{Key: "name", Value: "demo"}}
+	if path, err := c.ReadPath("name"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(path, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, path)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Resolve replaces the `${my.key}` references found in string values by the ReadString value of the referenced key.
+//
+// Resolve allows for values such as `${scheme}://${host}:${port}` to be computed from their sibling keys.
+// References to unknown keys are replaced by an empty string unless the KeepUnresolved option is provided.
+// Cyclic references result in an ErrReferenceCycle. Errors are aggregated per key as Errors.
+func Resolve(rw ReadWriter, opts ...Option) error {
+	w, ok := rw.(walker)
+	if !ok {
+		return &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+	}
+	// Collect the string leaves
+	var keys []string
+	err := w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		if e := indirect(element); e.Kind() == reflect.String && strings.Contains(e.String(), "${") {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r := &resolver{RW: rw, Options: newOptions(opts), Resolved: map[string]string{}, Resolving: map[string]bool{}}
+	var errs Errors
+	for _, key := range keys {
+		v, err := r.resolve(key)
+		if err == nil {
+			err = rw.Write(key, v)
+		}
+		if err != nil {
+			var kerr KeyError
+			if !errors.As(err, &kerr) {
+				kerr = &ConfigurationError{key}
+			}
+			errs = append(errs, kerr)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// resolver memoizes the resolved references while tracking the ones being resolved.
+type resolver struct {
+	RW        ReadWriter
+	Options   options
+	Resolved  map[string]string
+	Resolving map[string]bool
+}
+
+// resolve returns the value of a key with its references recursively replaced.
+func (r *resolver) resolve(key string) (string, error) {
+	id := foldKey(r.RW, key)
+	if v, ok := r.Resolved[id]; ok {
+		return v, nil
+	}
+	s, err := r.RW.ReadString(key)
+	if err != nil {
+		return "", err
+	}
+	r.Resolving[id] = true
+	defer delete(r.Resolving, id)
+	b := strings.Builder{}
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
+		ref := s[start+2 : end]
+		b.WriteString(s[:start])
+		if r.Resolving[foldKey(r.RW, ref)] {
+			return "", &ErrReferenceCycle{Reference: ref, ConfigurationError: &ConfigurationError{key}}
+		}
+		v, err := r.resolve(ref)
+		var nerr *ErrNoSuchKey
+		switch {
+		case errors.As(err, &nerr) && r.Options.keepUnresolved:
+			b.WriteString(s[start : end+1])
+		case errors.As(err, &nerr):
+		case err != nil:
+			return "", err
+		default:
+			b.WriteString(v)
+		}
+		s = s[end+1:]
+	}
+	b.WriteString(s)
+	r.Resolved[id] = b.String()
+	return r.Resolved[id], nil
+}
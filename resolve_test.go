@@ -0,0 +1,71 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestResolve(t *testing.T) {
+	type server struct {
+		Scheme string
+		Host   string
+		Port   int
+		URL    string
+	}
+	type data struct {
+		Server server
+		Extra  map[string]interface{}
+	}
+	d := &data{
+		Server: server{Scheme: "https", Host: "example.com", Port: 8443, URL: "${server.scheme}://${server.host}:${server.port}"},
+		Extra:  map[string]interface{}{"health": "${server.url}/health", "unknown": "${nope}"},
+	}
+	c := New(d)
+	if err := Resolve(c); err != nil {
+		t.Fatal(err)
+	}
+	if url := "https://example.com:8443"; d.Server.URL != url {
+		t.Fatalf("expected %#v, got %#v", url, d.Server.URL)
+	} else if health := url + "/health"; d.Extra["health"] != health {
+		t.Fatalf("expected %#v, got %#v", health, d.Extra["health"])
+	} else if d.Extra["unknown"] != "" {
+		t.Fatalf("expected %#v, got %#v", "", d.Extra["unknown"])
+	}
+}
+
+func TestResolveKeepUnresolved(t *testing.T) {
+	d := map[string]interface{}{"foo": "${bar}-${baz}", "baz": "baz"}
+	c := New(&d)
+	if err := Resolve(c, KeepUnresolved()); err != nil {
+		t.Fatal(err)
+	}
+	if foo := "${bar}-baz"; d["foo"] != foo {
+		t.Fatalf("expected %#v, got %#v", foo, d["foo"])
+	}
+}
+
+func TestResolveCycle(t *testing.T) {
+	d := map[string]interface{}{"foo": "${bar}", "bar": "${foo}", "baz": "${qux}", "qux": "qux"}
+	c := New(&d)
+	err := Resolve(c)
+	if err == nil {
+		t.Fatal("expected error but got none")
+	}
+	errs, ok := err.(Errors)
+	if !ok {
+		t.Fatalf("expected %T error, got %T error", errs, err)
+	} else if len(errs) != 2 {
+		t.Fatalf("expected %#v errors, got %#v", 2, len(errs))
+	}
+	for _, err := range errs {
+		if e, ok := err.(*ErrReferenceCycle); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		}
+	}
+	if d["baz"] != "qux" {
+		t.Fatalf("expected %#v, got %#v", "qux", d["baz"])
+	}
+}
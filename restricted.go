@@ -0,0 +1,61 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"strings"
+)
+
+// NewRestricted abstracts a ReadWriter whose writes are limited to a set of writable keys.
+//
+// A writable key also allows writes to all keys it prefixes, so that `server` enables writing both `server.host` and
+// `server.port`. Writing any other key results in an ErrKeyNotWritable while reads are left unaffected.
+func NewRestricted(rw ReadWriter, keys ...string) ReadWriter {
+	return &restricted{ReadWriter: rw, Keys: keys}
+}
+
+// restricted is a ReadWriter whose writes are limited to a set of writable keys and the keys they prefix.
+type restricted struct {
+	ReadWriter
+	Keys []string
+}
+
+// writable reports whether a key is writable.
+func (r *restricted) writable(key string) bool {
+	key = foldKey(r.ReadWriter, key)
+	sep := separatorOf(r.ReadWriter)
+	for _, k := range r.Keys {
+		k = foldKey(r.ReadWriter, k)
+		if key == k || strings.HasPrefix(key, k+sep) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write is a restricted wrapper around the Writer.
+func (r *restricted) Write(key string, v interface{}) error {
+	if !r.writable(key) {
+		return &ErrKeyNotWritable{&ConfigurationError{key}}
+	}
+	return r.ReadWriter.Write(key, v)
+}
+
+// ReadOnly abstracts a Reader as a ReadWriter whose writes always result in ErrReadOnly.
+//
+// ReadOnly allows handing a configuration to code requiring a ReadWriter while guaranteeing it can't be altered.
+func ReadOnly(r Reader) ReadWriter {
+	return &readOnly{Reader: r}
+}
+
+// readOnly is a ReadWriter rejecting all writes.
+type readOnly struct {
+	Reader
+}
+
+// Write rejects the write with ErrReadOnly.
+func (r *readOnly) Write(key string, v interface{}) error {
+	return ErrReadOnly
+}
@@ -0,0 +1,81 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+)
+
+func TestNewRestricted_Write(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name   string
+		Admin  string
+		Server server
+	}
+	d := &data{}
+	c := NewRestricted(New(d), "name", "Server")
+	if err := c.Write("name", "demo"); err != nil {
+		t.Fatal(err)
+	} else if d.Name != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", d.Name)
+	}
+	if err := c.Write("server.port", 8080); err != nil {
+		t.Fatal(err)
+	} else if d.Server.Port != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, d.Server.Port)
+	}
+}
+
+func TestNewRestricted_WriteDenied(t *testing.T) {
+	type data struct {
+		Name      string
+		Namespace string
+	}
+	d := &data{Namespace: "default"}
+	c := NewRestricted(New(d), "name")
+	if err := c.Write("namespace", "kube-system"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrKeyNotWritable); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if d.Namespace != "default" {
+		t.Fatalf("expected %#v, got %#v", "default", d.Namespace)
+	}
+	if s, err := c.ReadString("namespace"); err != nil {
+		t.Fatal(err)
+	} else if s != "default" {
+		t.Fatalf("expected %#v, got %#v", "default", s)
+	}
+}
+
+func TestReadOnly(t *testing.T) {
+	d := map[string]interface{}{"name": "demo"}
+	c := ReadOnly(New(&d))
+	if v, err := c.ReadString("name"); err != nil {
+		t.Fatal(err)
+	} else if v != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", v)
+	}
+	if err := Sub(c, "profile").Write("name", "prod"); err != ErrReadOnly {
+		t.Fatalf("expected %#v, got %#v", ErrReadOnly, err)
+	} else if d["name"] != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", d["name"])
+	}
+}
+
+func TestNewRestricted_CaseSensitive(t *testing.T) {
+	d := map[string]string{}
+	r := NewRestricted(NewCaseSensitive(&d), "ID")
+	if err := r.Write("ID", "a"); err != nil {
+		t.Fatal(err)
+	}
+	err := r.Write("id", "b")
+	if e, ok := err.(*ErrKeyNotWritable); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ValidateAgainst checks that every leaf of a Reader is part of the schema, a zero struct defining the expected
+// shape. The Reader must be enumerable, as are configurations created by New and Sub.
+//
+// Leaves absent from the schema result in an ErrUnknownKey while leaves whose kind isn't compatible with the schema
+// result in an ErrKindMismatch. Numeric kinds are compatible with one another and strings are compatible with types
+// parsed from text, such as encoding.TextUnmarshaler implementations and durations. All problems are aggregated as
+// Errors.
+func ValidateAgainst(r Reader, schema interface{}) error {
+	w, ok := r.(walker)
+	if !ok {
+		return &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+	}
+	s := reflect.TypeOf(schema)
+	var errs Errors
+	err := w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		t, ok := schemaType(s, strings.Split(key, separatorOf(r)))
+		switch {
+		case !ok:
+			errs = append(errs, &ErrUnknownKey{&ConfigurationError{key}})
+		case t != nil && !compatible(element, t):
+			kind := reflect.Invalid
+			if element.IsValid() {
+				kind = element.Kind()
+			}
+			errs = append(errs, &ErrKindMismatch{Kind: kind.String(), Expected: t.Kind().String(), ConfigurationError: &ConfigurationError{key}})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// schemaType resolves the type a key has within the schema type. The returned type is nil when the key is held by an
+// interface, in which case any value is accepted.
+func schemaType(t reflect.Type, key []string) (reflect.Type, bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if len(key) == 0 || t == nil {
+		return t, t != nil
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return nil, true
+	case reflect.Struct:
+		if f, ok := structField(t, key[0], false); ok {
+			return schemaType(t.Field(f).Type, key[1:])
+		}
+	case reflect.Map:
+		return schemaType(t.Elem(), key[1:])
+	case reflect.Slice, reflect.Array:
+		if _, err := strconv.Atoi(key[0]); err == nil {
+			return schemaType(t.Elem(), key[1:])
+		}
+	}
+	return nil, false
+}
+
+// compatible reports whether an element is compatible with a schema type.
+func compatible(element reflect.Value, t reflect.Type) bool {
+	for element.Kind() == reflect.Interface || element.Kind() == reflect.Ptr {
+		if element.IsNil() {
+			return true
+		}
+		element = element.Elem()
+	}
+	if !element.IsValid() || t.Kind() == reflect.Interface || element.Type().AssignableTo(t) {
+		return true
+	}
+	if element.Kind() == reflect.String && (t == durationType || reflect.PtrTo(t).Implements(textUnmarshaler)) {
+		return true
+	}
+	return kindClass(element.Kind()) == kindClass(t.Kind())
+}
+
+// kindClass groups the interchangeable kinds, numbers being converted into one another.
+func kindClass(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	default:
+		return k
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+type schema struct {
+	Name    string
+	Timeout time.Duration
+	Servers map[string]struct {
+		Host string
+		Port int
+	}
+	Tags  []string
+	Extra interface{}
+}
+
+func TestValidateAgainst(t *testing.T) {
+	d := map[string]interface{}{
+		"name":    "demo",
+		"timeout": "5s",
+		"servers": map[string]interface{}{"default": map[string]interface{}{"host": "localhost", "port": 8080.0}},
+		"tags":    []interface{}{"web"},
+		"extra":   map[string]interface{}{"anything": true},
+	}
+	if err := ValidateAgainst(New(&d), schema{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateAgainst_Unknown(t *testing.T) {
+	d := map[string]interface{}{"name": "demo", "nmae": "typo"}
+	err := ValidateAgainst(New(&d), schema{})
+	if e, ok := err.(Errors); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if len(e) != 1 {
+		t.Fatalf("expected %#v, got %#v", 1, len(e))
+	} else if u, ok := e[0].(*ErrUnknownKey); !ok {
+		t.Fatalf("expected %T error, got %T error", u, e[0])
+	} else if u.Key() != "nmae" {
+		t.Fatalf("expected %#v, got %#v", "nmae", u.Key())
+	}
+}
+
+func TestValidateAgainst_Mismatch(t *testing.T) {
+	d := map[string]interface{}{"servers": map[string]interface{}{"default": map[string]interface{}{"port": "http"}}}
+	err := ValidateAgainst(New(&d), &schema{})
+	if e, ok := err.(Errors); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if len(e) != 1 {
+		t.Fatalf("expected %#v, got %#v", 1, len(e))
+	} else if m, ok := e[0].(*ErrKindMismatch); !ok {
+		t.Fatalf("expected %T error, got %T error", m, e[0])
+	} else if m.Key() != "servers.default.port" || m.Expected != "int" {
+		t.Fatalf("expected %#v, got %#v", "servers.default.port", m.Key())
+	}
+}
+
+func TestValidateAgainst_Separator(t *testing.T) {
+	d := map[string]interface{}{
+		"servers": map[string]interface{}{"example.com": map[string]interface{}{"host": "localhost", "port": 8080.0}},
+	}
+	if err := ValidateAgainst(NewWithSeparator(&d, "/"), schema{}); err != nil {
+		t.Fatal(err)
+	}
+}
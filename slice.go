@@ -0,0 +1,154 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ReadIntSlice behaves like the Reader's Read with additional integer slice conversion taking place.
+// Comma-separated strings are split into their elements.
+func ReadIntSlice(r Reader, key string) ([]int64, error) {
+	e, err := elements(r, key)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]int64, len(e))
+	for i, v := range e {
+		n, kerr := toInt64(v)
+		if kerr != nil {
+			prependKey(kerr, strconv.Itoa(i), separatorOf(r))
+			prependKey(kerr, key, separatorOf(r))
+			return nil, kerr
+		}
+		s[i] = n
+	}
+	return s, nil
+}
+
+// ReadFloatSlice behaves like the Reader's Read with additional float slice conversion taking place.
+// Comma-separated strings are split into their elements.
+func ReadFloatSlice(r Reader, key string) ([]float64, error) {
+	e, err := elements(r, key)
+	if err != nil {
+		return nil, err
+	}
+	s := make([]float64, len(e))
+	for i, v := range e {
+		f, kerr := toFloat64(v)
+		if kerr != nil {
+			prependKey(kerr, strconv.Itoa(i), separatorOf(r))
+			prependKey(kerr, key, separatorOf(r))
+			return nil, kerr
+		}
+		s[i] = f
+	}
+	return s, nil
+}
+
+// elements reads the elements of a slice, array or comma-separated string.
+// Any other kind results in an ErrKindMismatch.
+func elements(r Reader, key string) ([]interface{}, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	e, kerr := toElements(v)
+	if kerr != nil {
+		kerr.From(key)
+		return nil, kerr
+	}
+	return e, nil
+}
+
+// toElements converts a slice, array or comma-separated string into its elements.
+func toElements(v interface{}) ([]interface{}, KeyError) {
+	val := indirect(reflect.ValueOf(v))
+	switch k := val.Kind(); k {
+	case reflect.Slice, reflect.Array:
+		e := make([]interface{}, val.Len())
+		for i := range e {
+			e[i] = val.Index(i).Interface()
+		}
+		return e, nil
+	case reflect.String:
+		if val.Len() == 0 {
+			return []interface{}{}, nil
+		}
+		parts := strings.Split(val.String(), ",")
+		e := make([]interface{}, len(parts))
+		for i, p := range parts {
+			e[i] = strings.TrimSpace(p)
+		}
+		return e, nil
+	default:
+		return nil, &ErrKindMismatch{Kind: k.String(), Expected: reflect.Slice.String(), ConfigurationError: &ConfigurationError{}}
+	}
+}
+
+// ReadJoined behaves like the Reader's Read with the stringified elements of a slice or array joined by a separator.
+// Any other kind results in an ErrKindMismatch.
+func ReadJoined(r Reader, key string, sep string) (string, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return "", err
+	}
+	val := indirect(reflect.ValueOf(v))
+	if k := val.Kind(); k != reflect.Slice && k != reflect.Array {
+		return "", &ErrKindMismatch{Kind: k.String(), Expected: reflect.Slice.String(), ConfigurationError: &ConfigurationError{key}}
+	}
+	s := make([]string, val.Len())
+	for i := range s {
+		e, kerr := toString(val.Index(i).Interface())
+		if kerr != nil {
+			prependKey(kerr, strconv.Itoa(i), separatorOf(r))
+			prependKey(kerr, key, separatorOf(r))
+			return "", kerr
+		}
+		s[i] = e
+	}
+	return strings.Join(s, sep), nil
+}
+
+// ReadStringSlice behaves like the Reader's Read with additional string slice conversion taking place.
+// Comma-separated strings are split into their elements while other scalars are wrapped in a single-element slice.
+// Elements are converted like ReadString does.
+func ReadStringSlice(r Reader, key string) ([]string, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := v.([]string); ok {
+		return s, nil
+	}
+	switch indirect(reflect.ValueOf(v)).Kind() {
+	case reflect.Slice, reflect.Array, reflect.String:
+	default:
+		str, kerr := toString(v)
+		if kerr != nil {
+			kerr.From(key)
+			return nil, kerr
+		}
+		return []string{str}, nil
+	}
+	e, kerr := toElements(v)
+	if kerr != nil {
+		kerr.From(key)
+		return nil, kerr
+	}
+	s := make([]string, len(e))
+	for i, v := range e {
+		str, kerr := toString(v)
+		if kerr != nil {
+			prependKey(kerr, strconv.Itoa(i), separatorOf(r))
+			prependKey(kerr, key, separatorOf(r))
+			return nil, kerr
+		}
+		s[i] = str
+	}
+	return s, nil
+}
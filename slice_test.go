@@ -0,0 +1,105 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadIntSlice(t *testing.T) {
+	d := map[string]interface{}{
+		"native": []int{80, 443},
+		"mixed":  []interface{}{80, 443.0, "8080"},
+		"string": "80, 443,8080",
+		"broken": []interface{}{80, "http"},
+		"scalar": 80,
+	}
+	c := New(&d)
+	for key, expected := range map[string][]int64{"native": {80, 443}, "mixed": {80, 443, 8080}, "string": {80, 443, 8080}} {
+		if s, err := ReadIntSlice(c, key); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(s, expected) {
+			t.Fatalf("expected %#v, got %#v", expected, s)
+		}
+	}
+	if _, err := ReadIntSlice(c, "broken"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "broken.1" {
+		t.Fatalf("expected %#v, got %#v", "broken.1", e.Key())
+	}
+	if _, err := ReadIntSlice(c, "scalar"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrKindMismatch); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestReadFloatSlice(t *testing.T) {
+	d := map[string]interface{}{
+		"native": []float64{0.5, 1.5},
+		"mixed":  []interface{}{1, 0.25, "2.5"},
+		"string": "0.1,0.2",
+	}
+	c := New(&d)
+	for key, expected := range map[string][]float64{"native": {0.5, 1.5}, "mixed": {1, 0.25, 2.5}, "string": {0.1, 0.2}} {
+		if s, err := ReadFloatSlice(c, key); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(s, expected) {
+			t.Fatalf("expected %#v, got %#v", expected, s)
+		}
+	}
+}
+
+func TestReadJoined(t *testing.T) {
+	d := map[string]interface{}{
+		"tags":   []string{"a", "b"},
+		"ports":  [2]int{80, 443},
+		"flags":  []interface{}{true, 1.5, "c"},
+		"scalar": "a, b",
+	}
+	c := New(&d)
+	for key, expected := range map[string]string{"tags": "a, b", "ports": "80, 443", "flags": "true, 1.5, c"} {
+		if s, err := ReadJoined(c, key, ", "); err != nil {
+			t.Fatal(err)
+		} else if s != expected {
+			t.Fatalf("expected %#v, got %#v", expected, s)
+		}
+	}
+	if _, err := ReadJoined(c, "scalar", ", "); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrKindMismatch); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
+
+func TestReadStringSlice(t *testing.T) {
+	tests := map[string][]string{
+		"native": {"a", "b"},
+		"list":   {"a", "1", "true"},
+		"comma":  {"a", "b", "c"},
+		"scalar": {"42"},
+		"empty":  {},
+		"single": {"a"},
+	}
+	d := map[string]interface{}{
+		"native": []string{"a", "b"},
+		"list":   []interface{}{"a", 1, true},
+		"comma":  "a, b ,c",
+		"scalar": 42,
+		"empty":  "",
+		"single": "a",
+	}
+	c := New(&d)
+	for key, expected := range tests {
+		if v, err := ReadStringSlice(c, key); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(v, expected) {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, key, v)
+		}
+	}
+}
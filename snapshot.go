@@ -0,0 +1,63 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+)
+
+func init() {
+	// Register the free-form shapes held by interfaces
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
+// Snapshotter abstracts a configuration which can be checkpointed and restored.
+//
+// Snapshots are gob-encoded, hence only exported fields are preserved. Concrete types held by interfaces other than
+// the basic types, `map[string]interface{}` and `[]interface{}` must be registered using gob.Register.
+type Snapshotter interface {
+	// Encode snapshots the whole configuration.
+	Encode() ([]byte, error)
+	// Decode restores the configuration from a snapshot of a compatible type.
+	Decode(data []byte) error
+}
+
+// Encode snapshots the whole configuration.
+func (c *config) Encode() ([]byte, error) {
+	b := &bytes.Buffer{}
+	if err := gob.NewEncoder(b).Encode(c.Data); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// Decode restores the configuration from a snapshot of a compatible type.
+// When the configuration is linked to a pointer, the pointed value is restored in place. Configurations linked to nil
+// or to a nil pointer result in an ErrIncompatibleType.
+func (c *config) Decode(data []byte) error {
+	d := reflect.ValueOf(c.Data)
+	if !d.IsValid() || (d.Kind() == reflect.Ptr && d.IsNil()) {
+		return &ErrIncompatibleType{Type: fmt.Sprintf("%T", c.Data), ConfigurationError: &ConfigurationError{}}
+	}
+	// Decode into a fresh value as gob leaves the fields absent from the snapshot untouched
+	t := d.Type()
+	if d.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	n := reflect.New(t)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(n.Interface()); err != nil {
+		return err
+	}
+	if d.Kind() == reflect.Ptr {
+		d.Elem().Set(n.Elem())
+		return nil
+	}
+	c.Data = n.Elem().Interface()
+	return nil
+}
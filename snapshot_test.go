@@ -0,0 +1,79 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_EncodeDecode(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name    string
+		Debug   bool
+		Servers map[string]server
+		Extra   map[string]interface{}
+	}
+	d := &data{
+		Name:    "demo",
+		Servers: map[string]server{"default": {Host: "localhost", Port: 80}},
+		Extra:   map[string]interface{}{"nested": map[string]interface{}{"foo": "bar"}},
+	}
+	c := New(d)
+	s := c.(Snapshotter)
+	b, err := s.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for key, v := range map[string]interface{}{"name": "changed", "debug": true, "servers.default.port": 8080, "servers.new.host": "remote", "extra.nested.foo": "baz"} {
+		if err := c.Write(key, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Decode(b); err != nil {
+		t.Fatal(err)
+	}
+	expected := &data{
+		Name:    "demo",
+		Servers: map[string]server{"default": {Host: "localhost", Port: 80}},
+		Extra:   map[string]interface{}{"nested": map[string]interface{}{"foo": "bar"}},
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+}
+
+func TestConfig_DecodeMap(t *testing.T) {
+	d := map[string]int{"foo": 1}
+	c := New(d)
+	s := c.(Snapshotter)
+	b, err := s.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Write("bar", 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Decode(b); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Read("bar"); err == nil {
+		t.Fatalf("expected key to be restored, got %#v", v)
+	}
+}
+
+func TestConfig_DecodeNil(t *testing.T) {
+	var d *map[string]int
+	for _, c := range []ReadWriter{New(nil), New(d)} {
+		err := c.(Snapshotter).Decode(nil)
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// StringWriter abstracts a configuration able to parse string values into the type of their key.
+type StringWriter interface {
+	// WriteString behaves like Write with the value parsed according to the type at key.
+	WriteString(key, value string) error
+}
+
+// WriteString behaves like Write with the value parsed according to the type at key, being the write-side counterpart
+// of ReadString. Integers, floats, booleans, durations and encoding.TextUnmarshaler implementations are parsed while
+// strings for keys without a type constraint, such as entries of free-form maps, are written as-is.
+// Strings which can't be parsed result in an ErrIncompatibleType.
+func (c *config) WriteString(key, value string) error {
+	t, err := c.target(key)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(value)
+	if t != nil {
+		v = reflect.New(t).Elem()
+		if err := parseString(v, value); err != nil {
+			return &ErrIncompatibleType{Type: t.String(), ConfigurationError: &ConfigurationError{key}}
+		}
+	}
+	return c.Write(key, v.Interface())
+}
+
+// target returns the type a key's value must have, absent keys having the element type of their parent.
+// The returned type is nil when the key has no type constraint.
+func (c *config) target(key string) (reflect.Type, error) {
+	ctx := context.Background()
+	d := reflect.ValueOf(c.Data)
+	k, err := c.split(key)
+	if err != nil {
+		return nil, err
+	}
+	v, err := c.read(ctx, k, d)
+	if errors.Is(err, ErrKeyNotFound) {
+		// Absent keys within maps and slices have their element type
+		if p, err := c.read(ctx, k[:len(k)-1], d); err == nil {
+			switch p = indirect(p); p.Kind() {
+			case reflect.Map, reflect.Slice, reflect.Array:
+				return constraint(p.Type().Elem()), nil
+			}
+		}
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	return constraint(v.Type()), nil
+}
+
+// constraint returns the type, or nil for interfaces which can hold strings as-is.
+func constraint(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Interface {
+		return nil
+	}
+	return t
+}
+
+// WriteString is a prefixed wrapper around the StringWriter.
+func (s *sub) WriteString(key, value string) error {
+	w, ok := s.RW.(StringWriter)
+	if !ok {
+		return &ErrUnsupported{Operation: "string parsing", ConfigurationError: &ConfigurationError{s.resolve(key)}}
+	}
+	return w.WriteString(s.resolve(key), value)
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig_WriteString(t *testing.T) {
+	type data struct {
+		Name    string
+		Port    int
+		Ratio   float64
+		Debug   bool
+		Timeout time.Duration
+		Retries *uint8
+		Limits  map[string]int
+		Extra   map[string]interface{}
+	}
+	d := &data{Extra: map[string]interface{}{"count": 1}}
+	c := New(d).(StringWriter)
+	for key, value := range map[string]string{
+		"name":         "demo",
+		"port":         "8080",
+		"ratio":        "0.5",
+		"debug":        "true",
+		"timeout":      "1m30s",
+		"retries":      "3",
+		"limits.conns": "100",
+		"extra.count":  "2",
+		"extra.label":  "web",
+	} {
+		if err := c.WriteString(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	retries := uint8(3)
+	expected := &data{
+		Name:    "demo",
+		Port:    8080,
+		Ratio:   0.5,
+		Debug:   true,
+		Timeout: 90 * time.Second,
+		Retries: &retries,
+		Limits:  map[string]int{"conns": 100},
+		Extra:   map[string]interface{}{"count": 2, "label": "web"},
+	}
+	if !reflect.DeepEqual(d, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, d)
+	}
+	for _, key := range []string{"port", "debug", "timeout", "limits.conns"} {
+		err := c.WriteString(key, "invalid")
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		} else if e.Key() != key {
+			t.Fatalf("expected %#v, got %#v", key, e.Key())
+		}
+	}
+}
+
+func TestSub_WriteString(t *testing.T) {
+	type server struct {
+		Port int
+	}
+	d := map[string]*server{"default": {}}
+	if err := Sub(New(&d), "default").(StringWriter).WriteString("port", "443"); err != nil {
+		t.Fatal(err)
+	} else if d["default"].Port != 443 {
+		t.Fatalf("expected %#v, got %#v", 443, d["default"].Port)
+	}
+}
+
+func TestConfig_WriteString_LeadingZeros(t *testing.T) {
+	var d struct {
+		Port  int
+		Count uint
+	}
+	c := New(&d).(StringWriter)
+	if err := c.WriteString("port", "0080"); err != nil {
+		t.Fatal(err)
+	} else if d.Port != 80 {
+		t.Fatalf("expected %#v, got %#v", 80, d.Port)
+	}
+	if err := c.WriteString("count", "010"); err != nil {
+		t.Fatal(err)
+	} else if d.Count != 10 {
+		t.Fatalf("expected %#v, got %#v", uint(10), d.Count)
+	}
+}
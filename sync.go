@@ -0,0 +1,40 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"sync"
+)
+
+// Synchronized abstracts a ReadWriter safe for concurrent use.
+//
+// Reads hold a shared lock while writes hold an exclusive lock, so that a background reloader may write while other
+// goroutines read. All typed reads such as ReadString are guarded as they are built on top of Read.
+func Synchronized(rw ReadWriter) ReadWriter {
+	s := &synchronized{RW: rw}
+	s.accessor = accessor{s.Read}
+	return s
+}
+
+// synchronized is a ReadWriter guarded by a read-write mutex.
+type synchronized struct {
+	accessor
+	RW ReadWriter
+	mu sync.RWMutex
+}
+
+// Read is a synchronized wrapper around the Reader.
+func (s *synchronized) Read(key string) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.RW.Read(key)
+}
+
+// Write is a synchronized wrapper around the Writer.
+func (s *synchronized) Write(key string, v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.RW.Write(key, v)
+}
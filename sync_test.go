@@ -0,0 +1,45 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestSynchronized(t *testing.T) {
+	d := map[string]interface{}{"server": map[string]interface{}{"port": 0}}
+	c := Synchronized(New(&d))
+	s := Sub(c, "server")
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := c.Write("server.port", i*100+j); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				v, err := s.ReadString("port")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := strconv.Atoi(v); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
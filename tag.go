@@ -0,0 +1,87 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// tagName is the struct tag key holding a field's configuration options.
+const tagName = "config"
+
+// tagOptions is the comma-separated list of options following a tag's name.
+type tagOptions string
+
+// parseTag splits a field's `config:"name,opt1,opt2"` tag into its name and options.
+func parseTag(f reflect.StructField) (string, tagOptions) {
+	tag := f.Tag.Get(tagName)
+	if i := strings.Index(tag, ","); i >= 0 {
+		return tag[:i], tagOptions(tag[i+1:])
+	}
+	return tag, ""
+}
+
+// Contains reports whether the options contain the provided option.
+func (o tagOptions) Contains(option string) bool {
+	s := string(o)
+	for s != "" {
+		var next string
+		if i := strings.Index(s, ","); i >= 0 {
+			s, next = s[:i], s[i+1:]
+		}
+		if s == option {
+			return true
+		}
+		s = next
+	}
+	return false
+}
+
+// secret reports whether a field is tagged as holding a secret value.
+func secret(f reflect.StructField) bool {
+	_, opts := parseTag(f)
+	return opts.Contains("secret")
+}
+
+// fieldKey returns the key addressing a struct field, which is its tag name or otherwise its lowercased name.
+// Fields tagged with `config:"-"` are not addressable.
+func fieldKey(f reflect.StructField) (string, bool) {
+	name, _ := parseTag(f)
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return strings.ToLower(f.Name), true
+	default:
+		return name, true
+	}
+}
+
+// fieldName returns the key matching a struct field, which is its tag name or otherwise its exact name when matched
+// exactly and its lowercased name otherwise.
+func fieldName(f reflect.StructField, exact bool) (string, bool) {
+	key, ok := fieldKey(f)
+	if name, _ := parseTag(f); ok && exact && name == "" {
+		key = f.Name
+	}
+	return key, ok
+}
+
+// Value returns the value of a `option=value` option, the value spanning until the next comma.
+func (o tagOptions) Value(option string) (string, bool) {
+	s := string(o)
+	for s != "" {
+		var next string
+		if i := strings.Index(s, ","); i >= 0 {
+			s, next = s[:i], s[i+1:]
+		}
+		if strings.HasPrefix(s, option+"=") {
+			return s[len(option)+1:], true
+		}
+		s = next
+	}
+	return "", false
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Tags(t *testing.T) {
+	type data struct {
+		APIKey   string `config:"api_key"`
+		Internal string `config:"-"`
+		Name     string `config:",secret"`
+	}
+	d := &data{APIKey: "key", Internal: "hidden", Name: "demo"}
+	c := New(d)
+	if v, err := c.ReadString("api_key"); err != nil {
+		t.Fatal(err)
+	} else if v != "key" {
+		t.Fatalf("expected %#v, got %#v", "key", v)
+	}
+	if v, err := c.ReadString("name"); err != nil {
+		t.Fatal(err)
+	} else if v != "demo" {
+		t.Fatalf("expected %#v, got %#v", "demo", v)
+	}
+	if err := c.Write("API_KEY", "updated"); err != nil {
+		t.Fatal(err)
+	} else if d.APIKey != "updated" {
+		t.Fatalf("expected %#v, got %#v", "updated", d.APIKey)
+	}
+	for _, key := range []string{"apikey", "internal"} {
+		if _, err := c.Read(key); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		} else if e, ok := err.(*ErrNoSuchKey); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		}
+		if err := c.Write(key, "value"); err == nil {
+			t.Fatalf("expected error for %#v but got none", key)
+		} else if e, ok := err.(*ErrNoSuchKey); !ok {
+			t.Fatalf("expected %T error, got %T error", e, err)
+		}
+	}
+	if d.Internal != "hidden" {
+		t.Fatalf("expected %#v, got %#v", "hidden", d.Internal)
+	}
+	expected := []string{"api_key", "name"}
+	if keys, err := c.(Enumerator).Keys(""); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"sort"
+)
+
+// KeysWithTag returns the sorted leaf keys of a Reader whose struct field carries the provided `config` tag option,
+// such that `editable` matches fields tagged `config:"name,editable"`. The Reader must be enumerable, as are
+// configurations created by New and Sub.
+//
+// Leaves which aren't struct fields, such as map values or slice elements, carry no tags and are never matched.
+func KeysWithTag(r Reader, option string) ([]string, error) {
+	w, ok := r.(walker)
+	if !ok {
+		return nil, &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{}}
+	}
+	var keys []string
+	err := w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		if field == nil {
+			return nil
+		}
+		if _, opts := parseTag(*field); opts.Contains(option) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
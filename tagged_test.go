@@ -0,0 +1,33 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeysWithTag(t *testing.T) {
+	type server struct {
+		Host string `config:"host,editable"`
+		Port int    `config:"port,advanced"`
+	}
+	type data struct {
+		Name    string `config:"name,editable"`
+		Server  server
+		Labels  map[string]string `config:"labels,editable"`
+		Servers map[string]server
+	}
+	d := &data{
+		Labels:  map[string]string{"env": "prod"},
+		Servers: map[string]server{"backup": {}},
+	}
+	expected := []string{"name", "server.host", "servers.backup.host"}
+	if keys, err := KeysWithTag(New(d), "editable"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+// TimeLayouts are the layouts ReadTime attempts, in order, when a string isn't in the RFC 3339 format.
+var TimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	time.RFC1123Z,
+	time.RFC1123,
+}
+
+// ReadTime behaves like the Reader's Read with additional time conversion taking place.
+// Strings are parsed using the RFC 3339 format, falling back to the TimeLayouts.
+func ReadTime(r Reader, key string) (time.Time, error) {
+	return readTime(r, key, append([]string{time.RFC3339}, TimeLayouts...))
+}
+
+// ReadTimeLayout behaves like the Reader's Read with additional time conversion taking place, strings being parsed with
+// the layout.
+func ReadTimeLayout(r Reader, key, layout string) (time.Time, error) {
+	return readTime(r, key, []string{layout})
+}
+
+// readTime reads a time, attempting each layout in order.
+func readTime(r Reader, key string, layouts []string) (time.Time, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case *time.Time:
+		if t != nil {
+			return *t, nil
+		}
+	case string:
+		s := strings.TrimSpace(t)
+		for _, layout := range layouts {
+			if p, err := time.Parse(layout, s); err == nil {
+				return p, nil
+			}
+		}
+	}
+	return time.Time{}, &ErrIncompatibleType{Type: "time.Time", ConfigurationError: &ConfigurationError{key}}
+}
@@ -0,0 +1,50 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadTime(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+	tests := map[interface{}]time.Time{
+		now:                         now,
+		"2021-06-01T12:30:00Z":      now,
+		"2021-06-01T14:30:00+02:00": now,
+		"2021-06-01 12:30:00":       now,
+		"2021-06-01":                time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for v, expected := range tests {
+		c := New(&map[string]interface{}{"start": v})
+		if r, err := ReadTime(c, "start"); err != nil {
+			t.Fatal(err)
+		} else if !r.Equal(expected) {
+			t.Fatalf("expected %#v for %#v, got %#v", expected, v, r)
+		}
+	}
+	for _, v := range []interface{}{"yesterday", 42} {
+		c := New(&map[string]interface{}{"start": v})
+		_, err := ReadTime(c, "start")
+		if e, ok := err.(*ErrIncompatibleType); !ok {
+			t.Fatalf("expected %T error for %#v, got %T error", e, v, err)
+		} else if e.Key() != "start" {
+			t.Fatalf("expected %#v, got %#v", "start", e.Key())
+		}
+	}
+}
+
+func TestReadTimeLayout(t *testing.T) {
+	c := New(&map[string]interface{}{"start": "01/06/2021"})
+	if r, err := ReadTimeLayout(c, "start", "02/01/2006"); err != nil {
+		t.Fatal(err)
+	} else if expected := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC); !r.Equal(expected) {
+		t.Fatalf("expected %#v, got %#v", expected, r)
+	}
+	if _, err := ReadTime(c, "start"); err == nil {
+		t.Fatal("expected error but got none")
+	}
+}
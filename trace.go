@@ -0,0 +1,48 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"sync"
+)
+
+// NewReadTracer abstracts a Reader recording every key being read.
+//
+// The returned function provides the deduplicated keys in the order they were first read, helping identify unused or
+// unexpectedly accessed configuration keys. Reads are delegated unchanged to the underlying Reader.
+func NewReadTracer(r Reader) (Reader, func() []string) {
+	t := &tracer{R: r, Seen: map[string]bool{}}
+	t.accessor = accessor{t.Read}
+	return t, t.traced
+}
+
+// tracer is a Reader recording every key being read.
+type tracer struct {
+	accessor
+	R    Reader
+	Keys []string
+	Seen map[string]bool
+	mu   sync.Mutex
+}
+
+// Read is a recording wrapper around the Reader.
+func (t *tracer) Read(key string) (interface{}, error) {
+	t.mu.Lock()
+	if k := foldKey(t.R, key); !t.Seen[k] {
+		t.Seen[k] = true
+		t.Keys = append(t.Keys, key)
+	}
+	t.mu.Unlock()
+	return t.R.Read(key)
+}
+
+// traced returns a copy of the recorded keys.
+func (t *tracer) traced() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	keys := make([]string, len(t.Keys))
+	copy(keys, t.Keys)
+	return keys
+}
@@ -0,0 +1,38 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewReadTracer(t *testing.T) {
+	type data struct {
+		Name string
+		Port int
+		Host string
+	}
+	d := &data{Name: "demo", Port: 80}
+	r, traced := NewReadTracer(New(d))
+	if s, err := r.ReadString("port"); err != nil {
+		t.Fatal(err)
+	} else if s != "80" {
+		t.Fatalf("expected %#v, got %#v", "80", s)
+	}
+	if _, err := r.Read("name"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read("Port"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Read("missing"); err == nil {
+		t.Fatal("expected error but got none")
+	}
+	expected := []string{"port", "name", "missing"}
+	if keys := traced(); !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+}
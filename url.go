@@ -0,0 +1,45 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"net/url"
+	"reflect"
+)
+
+// urlType is the type of url.URL, which strings are parsed into.
+var urlType = reflect.TypeOf(url.URL{})
+
+// ReadURL behaves like the Reader's Read with additional URL conversion taking place.
+// Strings are parsed using url.Parse.
+func ReadURL(r Reader, key string) (*url.URL, error) {
+	v, err := r.Read(key)
+	if err != nil {
+		return nil, err
+	}
+	u, kerr := toURL(v)
+	if kerr != nil {
+		kerr.From(key)
+		return nil, kerr
+	}
+	return u, nil
+}
+
+// toURL converts a value into a URL.
+func toURL(v interface{}) (*url.URL, KeyError) {
+	switch u := v.(type) {
+	case url.URL:
+		return &u, nil
+	case *url.URL:
+		if u != nil {
+			return u, nil
+		}
+	case string:
+		if p, err := url.Parse(u); err == nil {
+			return p, nil
+		}
+	}
+	return nil, &ErrIncompatibleType{Type: "*url.URL", ConfigurationError: &ConfigurationError{}}
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestReadURL(t *testing.T) {
+	type data struct {
+		Native *url.URL
+		String string
+		Broken string
+	}
+	d := &data{Native: &url.URL{Scheme: "https", Host: "example.com"}, String: "http://localhost:8080/api", Broken: "http://[::1"}
+	c := New(d)
+	if u, err := ReadURL(c, "native"); err != nil {
+		t.Fatal(err)
+	} else if u != d.Native {
+		t.Fatalf("expected %#v, got %#v", d.Native.String(), u.String())
+	}
+	if u, err := ReadURL(c, "string"); err != nil {
+		t.Fatal(err)
+	} else if u.String() != d.String {
+		t.Fatalf("expected %#v, got %#v", d.String, u.String())
+	}
+	if _, err := ReadURL(c, "broken"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "broken" {
+		t.Fatalf("expected %#v, got %#v", "broken", e.Key())
+	}
+}
+
+func TestConfig_WriteURL(t *testing.T) {
+	type data struct {
+		Value   url.URL
+		Pointer *url.URL
+	}
+	d := &data{}
+	c := New(d)
+	u := "https://example.com/path?query=1"
+	if err := c.Write("value", u); err != nil {
+		t.Fatal(err)
+	} else if d.Value.String() != u {
+		t.Fatalf("expected %#v, got %#v", u, d.Value.String())
+	}
+	if err := c.Write("pointer", u); err != nil {
+		t.Fatal(err)
+	} else if d.Pointer == nil || d.Pointer.String() != u {
+		t.Fatalf("expected %#v, got %#v", u, d.Pointer)
+	}
+	if err := c.Write("pointer", "http://[::1"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "pointer" {
+		t.Fatalf("expected %#v, got %#v", "pointer", e.Key())
+	}
+}
+
+func TestConfig_ReadURLValue(t *testing.T) {
+	d := map[string]interface{}{"endpoint": url.URL{Scheme: "https", Host: "example.com", Path: "/v1"}, "port": 8080}
+	c := New(&d)
+	if u, err := ReadURL(c, "endpoint"); err != nil {
+		t.Fatal(err)
+	} else if u.String() != "https://example.com/v1" {
+		t.Fatalf("expected %#v, got %#v", "https://example.com/v1", u.String())
+	}
+	if _, err := ReadURL(c, "port"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrIncompatibleType); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+	if _, err := ReadURL(c, "missing"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if e, ok := err.(*ErrNoSuchKey); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+// Validated abstracts a ReadWriter whose writes are validated beforehand.
+//
+// Validators are looked up by the written key, case-insensitively unless the ReadWriter is case-sensitive, the values
+// they reject resulting in an ErrInvalidValue wrapping the validator's error. Keys without validator are written as-is.
+func Validated(rw ReadWriter, validators map[string]func(interface{}) error) ReadWriter {
+	v := make(map[string]func(interface{}) error, len(validators))
+	for k, fn := range validators {
+		v[foldKey(rw, k)] = fn
+	}
+	return &validated{ReadWriter: rw, Validators: v}
+}
+
+// validated is a ReadWriter validating its writes.
+type validated struct {
+	ReadWriter
+	Validators map[string]func(interface{}) error
+}
+
+// Write is a validating wrapper around the Writer.
+func (v *validated) Write(key string, value interface{}) error {
+	if fn, ok := v.Validators[foldKey(v.ReadWriter, key)]; ok {
+		if err := fn(value); err != nil {
+			return &ErrInvalidValue{Err: err, ConfigurationError: &ConfigurationError{key}}
+		}
+	}
+	return v.ReadWriter.Write(key, value)
+}
@@ -0,0 +1,42 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidated(t *testing.T) {
+	errRange := errors.New("port out of range")
+	d := map[string]interface{}{"server": map[string]interface{}{"port": 80}}
+	rw := Validated(New(&d), map[string]func(interface{}) error{
+		"Server.Port": func(v interface{}) error {
+			if p, ok := v.(int); !ok || p < 1 || p > 65535 {
+				return errRange
+			}
+			return nil
+		},
+	})
+	if err := rw.Write("server.port", 8080); err != nil {
+		t.Fatal(err)
+	}
+	err := rw.Write("SERVER.PORT", 70000)
+	if e, ok := err.(*ErrInvalidValue); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Key() != "SERVER.PORT" {
+		t.Fatalf("expected %#v, got %#v", "SERVER.PORT", e.Key())
+	} else if !errors.Is(err, errRange) {
+		t.Fatalf("expected %#v, got %#v", errRange, e.Err)
+	}
+	if v, err := ReadInt(rw, "server.port"); err != nil {
+		t.Fatal(err)
+	} else if v != 8080 {
+		t.Fatalf("expected %#v, got %#v", 8080, v)
+	}
+	if err := rw.Write("server.host", "localhost"); err != nil {
+		t.Fatal(err)
+	}
+}
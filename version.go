@@ -0,0 +1,77 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"sync"
+)
+
+// VersionedReadWriter abstracts a ReadWriter whose successful writes increment a version.
+type VersionedReadWriter interface {
+	ReadWriter
+	// Version gets the current version, starting at 0 and incremented on every successful write.
+	Version() uint64
+	// WriteCAS sets the value of a key only if the current version matches the expected version, returning an
+	// ErrVersionConflict otherwise.
+	WriteCAS(key string, v interface{}, expected uint64) error
+}
+
+// NewVersioned abstracts a ReadWriter with a version token enabling compare-and-swap writes.
+//
+// Reads and writes are serialized, such that concurrent editors may safely use WriteCAS to ensure their changes are
+// based on the latest version.
+func NewVersioned(rw ReadWriter) VersionedReadWriter {
+	v := &versioned{RW: rw}
+	v.accessor = accessor{v.Read}
+	return v
+}
+
+// versioned is a ReadWriter whose successful writes increment a version.
+type versioned struct {
+	accessor
+	RW      ReadWriter
+	version uint64
+	mu      sync.RWMutex
+}
+
+// Read is a serialized wrapper around the Reader.
+func (v *versioned) Read(key string) (interface{}, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.RW.Read(key)
+}
+
+// Write is a versioned wrapper around the Writer.
+func (v *versioned) Write(key string, value interface{}) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.write(key, value)
+}
+
+// WriteCAS sets the value of a key only if the current version matches the expected version.
+func (v *versioned) WriteCAS(key string, value interface{}, expected uint64) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.version != expected {
+		return &ErrVersionConflict{Expected: expected, Actual: v.version, ConfigurationError: &ConfigurationError{key}}
+	}
+	return v.write(key, value)
+}
+
+// Version gets the current version.
+func (v *versioned) Version() uint64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.version
+}
+
+// write sets the value and increments the version on success, the lock being held by the caller.
+func (v *versioned) write(key string, value interface{}) error {
+	if err := v.RW.Write(key, value); err != nil {
+		return err
+	}
+	v.version++
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNewVersioned_WriteCAS(t *testing.T) {
+	d := map[string]interface{}{"name": "demo"}
+	c := NewVersioned(New(&d))
+	if v := c.Version(); v != 0 {
+		t.Fatalf("expected %#v, got %#v", uint64(0), v)
+	}
+	if err := c.WriteCAS("name", "first", 0); err != nil {
+		t.Fatal(err)
+	}
+	err := c.WriteCAS("name", "second", 0)
+	if e, ok := err.(*ErrVersionConflict); !ok {
+		t.Fatalf("expected %T error, got %T error", e, err)
+	} else if e.Actual != 1 {
+		t.Fatalf("expected %#v, got %#v", uint64(1), e.Actual)
+	}
+	if v, err := c.ReadString("name"); err != nil {
+		t.Fatal(err)
+	} else if v != "first" {
+		t.Fatalf("expected %#v, got %#v", "first", v)
+	}
+	if err := c.Write("name.first", "demo"); err == nil {
+		t.Fatal("expected error but got none")
+	} else if v := c.Version(); v != 1 {
+		t.Fatalf("expected %#v, got %#v", uint64(1), v)
+	}
+}
+
+func TestNewVersioned_Concurrent(t *testing.T) {
+	d := map[string]interface{}{"counter": 0}
+	c := NewVersioned(New(&d))
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; {
+				version := c.Version()
+				v, err := c.Read("counter")
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if err := c.WriteCAS("counter", v.(int)+1, version); err == nil {
+					j++
+				} else if _, ok := err.(*ErrVersionConflict); !ok {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if v, err := c.Read("counter"); err != nil {
+		t.Fatal(err)
+	} else if v != 800 {
+		t.Fatalf("expected %#v, got %#v", 800, v)
+	}
+	if v := c.Version(); v != 800 {
+		t.Fatalf("expected %#v, got %#v", uint64(800), v)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Enumerator abstracts a configuration whose keys can be listed.
+type Enumerator interface {
+	// Keys returns the sorted fully-qualified keys of all leaves found under the prefix, an empty prefix enumerating
+	// the whole configuration.
+	Keys(prefix string) ([]string, error)
+	// Walk calls fn with the fully-qualified key and value of every leaf, stopping at and returning the first error.
+	Walk(fn func(key string, value interface{}) error) error
+}
+
+// walker abstracts a configuration whose leaves can be enumerated.
+type walker interface {
+	// walk calls fn for every leaf found under the prefix, an empty prefix walking the whole configuration.
+	walk(prefix string, fn walkFunc) error
+}
+
+// walkFunc is called for every leaf with its fully-qualified key. When the leaf is a struct field, field describes it.
+type walkFunc func(key string, element reflect.Value, field *reflect.StructField) error
+
+// visitFunc is called for every leaf with the levels of its key, such that levels containing the separator are kept
+// apart. When the leaf is a struct field, field describes it.
+type visitFunc func(key []string, element reflect.Value, field *reflect.StructField) error
+
+// textMarshaler is the type of encoding.TextMarshaler, whose implementations are considered leaves.
+var textMarshaler = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// Keys returns the sorted fully-qualified keys of all leaves found under the prefix.
+func (c *config) Keys(prefix string) ([]string, error) {
+	return keys(c, prefix)
+}
+
+// Keys returns the sorted keys of all leaves found under the prefix, relative to the sub prefix.
+func (s *subReader) Keys(prefix string) ([]string, error) {
+	return keys(s, prefix)
+}
+
+// Walk calls fn with the fully-qualified key and value of every leaf, stopping at and returning the first error.
+func (c *config) Walk(fn func(key string, value interface{}) error) error {
+	return walkValues(c, fn)
+}
+
+// Walk calls fn with the key and value of every leaf, relative to the sub prefix.
+func (s *subReader) Walk(fn func(key string, value interface{}) error) error {
+	return walkValues(s, fn)
+}
+
+// walkValues calls fn with the key and value of every leaf a walker finds.
+func walkValues(w walker, fn func(key string, value interface{}) error) error {
+	return w.walk("", func(key string, element reflect.Value, field *reflect.StructField) error {
+		v, err := leafValue(key, element)
+		if err != nil {
+			return err
+		}
+		return fn(key, v)
+	})
+}
+
+// leafValue returns the value of a leaf as read, routing it through the read handler of its registered type.
+func leafValue(key string, element reflect.Value) (interface{}, error) {
+	if !element.IsValid() {
+		return nil, nil
+	}
+	v, err := handle(handledType(element), element.Interface(), false)
+	if err != nil {
+		err.From(key)
+		return nil, err
+	}
+	return v, nil
+}
+
+// keys returns the sorted keys of all leaves a walker finds under the prefix.
+func keys(w walker, prefix string) ([]string, error) {
+	var keys []string
+	err := w.walk(prefix, func(key string, element reflect.Value, field *reflect.StructField) error {
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// walk calls fn for every leaf found under the prefix, enforcing the maximal number of keys.
+func (c *config) walk(prefix string, fn walkFunc) error {
+	if max := c.Options.maxKeys; max > 0 {
+		count, inner := 0, fn
+		fn = func(key string, element reflect.Value, field *reflect.StructField) error {
+			if count++; count > max {
+				return &ErrLimitExceeded{Limit: "keys", Max: max, ConfigurationError: &ConfigurationError{key}}
+			}
+			return inner(key, element, field)
+		}
+	}
+	d := reflect.ValueOf(c.Data)
+	visit := func(key []string, element reflect.Value, field *reflect.StructField) error {
+		return fn(c.join(key), element, field)
+	}
+	if prefix == "" {
+		return c.visit(nil, d, nil, visit)
+	}
+	k, err := c.split(prefix)
+	if err != nil {
+		return err
+	}
+	e, err := c.read(context.Background(), k, d)
+	if err != nil {
+		return err
+	}
+	return c.visit(k, e, nil, visit)
+}
+
+// visit recursively descends an element, calling fn for each of its leaves.
+// Struct fields are keyed by their tag or name, lowercased unless the configuration is case-sensitive, to match the
+// lookups.
+// Descending beyond the maximal depth results in an ErrLimitExceeded.
+func (c *config) visit(key []string, element reflect.Value, field *reflect.StructField, fn visitFunc) error {
+	if max := c.Options.maxDepth; max > 0 && len(key) > max {
+		return &ErrLimitExceeded{Limit: "depth", Max: max, ConfigurationError: &ConfigurationError{c.join(key)}}
+	}
+	if element.IsValid() && element.Type().Implements(textMarshaler) {
+		return fn(key, element, field)
+	}
+	switch element.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if !element.IsNil() {
+			return c.visit(key, element.Elem(), field, fn)
+		}
+	case reflect.Struct:
+		t := element.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, ok := fieldName(f, c.Options.caseSensitive)
+			if f.PkgPath != "" || !ok {
+				continue
+			}
+			if err := c.visit(append(key, name), element.Field(i), &f, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		i := element.MapRange()
+		for i.Next() {
+			if err := c.visit(append(key, fmt.Sprint(i.Key().Interface())), i.Value(), nil, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return fn(key, element, field)
+}
+
+// walk calls fn for every leaf found under the prefix, relative to the sub prefix.
+func (s *subReader) walk(prefix string, fn walkFunc) error {
+	w, ok := s.R.(walker)
+	if !ok {
+		return &ErrUnsupported{Operation: "enumeration", ConfigurationError: &ConfigurationError{s.Prefix}}
+	}
+	p := s.Prefix
+	if prefix != "" {
+		p = s.resolve(prefix)
+	}
+	return w.walk(p, func(key string, element reflect.Value, field *reflect.StructField) error {
+		return fn(strings.TrimPrefix(strings.TrimPrefix(key, s.Prefix), s.separator()), element, field)
+	})
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Maxime THIEBAUT. All rights reserved.
+// Use of this source code is governed by EUPL-1.2
+// license that can be found in the LICENSE file.
+
+package config
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestConfig_Keys(t *testing.T) {
+	type server struct {
+		Host string
+		Port int
+	}
+	type data struct {
+		Name    string
+		Primary *server
+		Servers map[string]interface{}
+	}
+	d := &data{
+		Name:    "demo",
+		Primary: &server{Host: "localhost", Port: 80},
+		Servers: map[string]interface{}{"backup": &server{Host: "remote"}, "tags": []string{"a"}},
+	}
+	c := New(d).(Enumerator)
+	expected := []string{"name", "primary.host", "primary.port", "servers.backup.host", "servers.backup.port", "servers.tags"}
+	if keys, err := c.Keys(""); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+	expected = []string{"servers.backup.host", "servers.backup.port"}
+	if keys, err := c.Keys("servers.backup"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+	expected = []string{"host", "port"}
+	if keys, err := Sub(New(d), "primary").(Enumerator).Keys(""); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(keys, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, keys)
+	}
+}
+
+func TestConfig_Walk(t *testing.T) {
+	type server struct {
+		Host string
+	}
+	d := map[string]interface{}{"name": "demo", "server": &server{Host: "localhost"}}
+	c := New(&d).(Enumerator)
+	values := map[string]interface{}{}
+	if err := c.Walk(func(key string, value interface{}) error {
+		values[key] = value
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if expected := map[string]interface{}{"name": "demo", "server.host": "localhost"}; !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, values)
+	}
+	stop := errors.New("stop")
+	count := 0
+	if err := c.Walk(func(key string, value interface{}) error {
+		count++
+		return stop
+	}); err != stop {
+		t.Fatalf("expected %#v, got %#v", stop, err)
+	} else if count != 1 {
+		t.Fatalf("expected %#v, got %#v", 1, count)
+	}
+}